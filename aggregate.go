@@ -0,0 +1,135 @@
+package crud
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/itsLeonB/go-crud/internal"
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm/clause"
+)
+
+// AggregateFunc is a SQL aggregate function applied by Repository.Aggregate.
+type AggregateFunc string
+
+const (
+	AggregateSum AggregateFunc = "SUM"
+	AggregateAvg AggregateFunc = "AVG"
+	AggregateMin AggregateFunc = "MIN"
+	AggregateMax AggregateFunc = "MAX"
+)
+
+func (fn AggregateFunc) valid() bool {
+	switch fn {
+	case AggregateSum, AggregateAvg, AggregateMin, AggregateMax:
+		return true
+	default:
+		return false
+	}
+}
+
+func (gr *gormRepository[T]) Count(ctx context.Context, spec Specification[T]) (int64, error) {
+	db, err := gr.GetGormInstance(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	if err = db.Model(new(T)).Scopes(gr.filterScopes(spec)...).Count(&count).Error; err != nil {
+		return 0, eris.Wrap(internal.ClassifyError(err), "error counting data")
+	}
+
+	return count, nil
+}
+
+func (gr *gormRepository[T]) Exists(ctx context.Context, spec Specification[T]) (bool, error) {
+	db, err := gr.GetGormInstance(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var found []int
+	err = db.Model(new(T)).
+		Scopes(gr.filterScopes(spec)...).
+		Select("1").
+		Limit(1).
+		Find(&found).
+		Error
+
+	if err != nil {
+		return false, eris.Wrap(internal.ClassifyError(err), "error querying data")
+	}
+
+	return len(found) > 0, nil
+}
+
+func (gr *gormRepository[T]) Aggregate(ctx context.Context, spec Specification[T], fn AggregateFunc, field string, dest any) error {
+	if !fn.valid() {
+		return eris.Errorf("unsupported aggregate function: %s", fn)
+	}
+
+	meta, err := gr.meta()
+	if err != nil {
+		return err
+	}
+
+	column, err := internal.LookupField(meta.schema, field)
+	if err != nil {
+		return err
+	}
+
+	db, err := gr.GetGormInstance(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows, err := db.Model(new(T)).
+		Scopes(gr.filterScopes(spec)...).
+		Select(string(fn)+"(?)", clause.Column{Table: clause.CurrentTable, Name: column.DBName}).
+		Rows()
+
+	if err != nil {
+		return eris.Wrap(internal.ClassifyError(err), "error aggregating data")
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		err = rows.Scan(dest)
+	}
+	if err == nil {
+		err = rows.Err()
+	}
+	if err != nil {
+		return eris.Wrap(internal.ClassifyError(err), "error aggregating data")
+	}
+
+	return nil
+}
+
+// Sum returns the sum of field over the records matching spec, or the zero value when none match.
+func Sum[V, T any](ctx context.Context, repo Repository[T], spec Specification[T], field string) (V, error) {
+	return aggregate[V](ctx, repo, spec, AggregateSum, field)
+}
+
+// Avg returns the average of field over the records matching spec, or zero when none match.
+func Avg[T any](ctx context.Context, repo Repository[T], spec Specification[T], field string) (float64, error) {
+	return aggregate[float64](ctx, repo, spec, AggregateAvg, field)
+}
+
+// Min returns the smallest value of field over the records matching spec, or the zero value when none match.
+func Min[V, T any](ctx context.Context, repo Repository[T], spec Specification[T], field string) (V, error) {
+	return aggregate[V](ctx, repo, spec, AggregateMin, field)
+}
+
+// Max returns the largest value of field over the records matching spec, or the zero value when none match.
+func Max[V, T any](ctx context.Context, repo Repository[T], spec Specification[T], field string) (V, error) {
+	return aggregate[V](ctx, repo, spec, AggregateMax, field)
+}
+
+func aggregate[V, T any](ctx context.Context, repo Repository[T], spec Specification[T], fn AggregateFunc, field string) (V, error) {
+	var result sql.Null[V]
+	if err := repo.Aggregate(ctx, spec, fn, field, &result); err != nil {
+		return result.V, err
+	}
+	return result.V, nil
+}
@@ -21,3 +21,10 @@ func (be BaseEntity) IsZero() bool {
 func (be BaseEntity) IsDeleted() bool {
 	return be.DeletedAt.Valid
 }
+
+// VersionedEntity adds an optimistic locking version to a model. Embed it alongside BaseEntity.
+// Repositories check and increment Version on every update and return ErrStaleEntity
+// when the record was changed since it was loaded.
+type VersionedEntity struct {
+	Version int64 `gorm:"not null;default:1"`
+}
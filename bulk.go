@@ -0,0 +1,154 @@
+package crud
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/itsLeonB/go-crud/internal"
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+)
+
+// BulkOption configures UpdateWhere and DeleteWhere.
+type BulkOption func(*bulkConfig)
+
+type bulkConfig struct {
+	allowFullTable bool
+	hardDelete     bool
+}
+
+// AllowFullTable lets a bulk operation run with a specification that has no conditions,
+// affecting every record that passes the deleted filter.
+func AllowFullTable() BulkOption {
+	return func(cfg *bulkConfig) {
+		cfg.allowFullTable = true
+	}
+}
+
+// HardDelete makes DeleteWhere remove records even when the model has a deleted column.
+func HardDelete() BulkOption {
+	return func(cfg *bulkConfig) {
+		cfg.hardDelete = true
+	}
+}
+
+func newBulkConfig(opts []BulkOption) bulkConfig {
+	var cfg bulkConfig
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	return cfg
+}
+
+func (gr *gormRepository[T]) UpdateWhere(ctx context.Context, spec Specification[T], changes map[string]any, opts ...BulkOption) (int64, error) {
+	if len(changes) < 1 {
+		return 0, eris.New("updated fields cannot be empty")
+	}
+
+	meta, err := gr.meta()
+	if err != nil {
+		return 0, err
+	}
+
+	columns := make(map[string]any, len(changes))
+	for name, value := range changes {
+		field, err := updatableField(meta.schema, name)
+		if err != nil {
+			return 0, err
+		}
+		columns[field.DBName] = value
+	}
+	if meta.versionColumn != "" {
+		columns[meta.versionColumn] = meta.versionIncrement()
+	}
+
+	db, err := gr.bulkInstance(ctx, meta, spec, newBulkConfig(opts))
+	if err != nil {
+		return 0, err
+	}
+
+	result := db.Model(new(T)).
+		Scopes(gr.filterScopes(spec)...).
+		Updates(columns)
+
+	if result.Error != nil {
+		return 0, eris.Wrap(internal.ClassifyError(result.Error), "error bulk updating data")
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (gr *gormRepository[T]) DeleteWhere(ctx context.Context, spec Specification[T], opts ...BulkOption) (int64, error) {
+	cfg := newBulkConfig(opts)
+
+	meta, err := gr.meta()
+	if err != nil {
+		return 0, err
+	}
+
+	db, err := gr.bulkInstance(ctx, meta, spec, cfg)
+	if err != nil {
+		return 0, err
+	}
+
+	var result *gorm.DB
+	if meta.deletedColumn == "" || cfg.hardDelete {
+		result = db.Unscoped().
+			Scopes(gr.filterScopes(spec)...).
+			Delete(new(T))
+	} else {
+		result = db.Model(new(T)).
+			Scopes(gr.filterScopes(spec)...).
+			Update(meta.deletedColumn, db.NowFunc())
+	}
+
+	if result.Error != nil {
+		return 0, eris.Wrap(internal.ClassifyError(result.Error), "error bulk deleting data")
+	}
+
+	return result.RowsAffected, nil
+}
+
+// bulkInstance returns the transaction-aware DB for a bulk operation,
+// refusing a specification without conditions unless the full table is explicitly allowed.
+func (gr *gormRepository[T]) bulkInstance(ctx context.Context, meta *modelMeta, spec Specification[T], cfg bulkConfig) (*gorm.DB, error) {
+	db, err := gr.GetGormInstance(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.allowFullTable {
+		return db.Session(&gorm.Session{AllowGlobalUpdate: true}), nil
+	}
+
+	hasConditions := len(spec.Fields) > 0 || hasColumnValues(ctx, meta, spec.Model)
+	if !hasConditions && spec.Filter != nil {
+		expr, err := spec.Filter.expression(&filterContext{db: db, schema: meta.schema})
+		if err != nil {
+			return nil, err
+		}
+		hasConditions = expr != nil && !isMatchAll(expr)
+	}
+	if !hasConditions {
+		return nil, eris.New("refusing bulk operation without conditions, pass AllowFullTable to affect every record")
+	}
+
+	return db, nil
+}
+
+// hasColumnValues reports whether model has a non-zero column, as only those become conditions
+// when GORM queries by struct. Relations and fields that are not columns are ignored.
+func hasColumnValues[T any](ctx context.Context, meta *modelMeta, model T) bool {
+	rv := reflect.ValueOf(&model).Elem()
+	for _, field := range meta.schema.Fields {
+		if field.DBName == "" {
+			continue
+		}
+		if _, isZero := field.ValueOf(ctx, rv); !isZero {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,253 @@
+package crud
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/itsLeonB/go-crud/internal"
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// CursorRequest describes a page of keyset (cursor) pagination.
+// Set After to the NextCursor of a previous page to move forward, or Before to its PrevCursor to move backward.
+// Sort defaults to the repository's default order; the primary key is always appended as a tiebreaker.
+// Sort.Nulls is not supported, and Specification.OrderBy, Limit and Offset are ignored.
+type CursorRequest struct {
+	After  string
+	Before string
+	Limit  int
+	Sort   []Sort
+}
+
+// CursorPage is a single page of results returned by FindCursor.
+// NextCursor and PrevCursor are opaque tokens and are empty when there is no page in that direction.
+type CursorPage[T any] struct {
+	Items      []T
+	NextCursor string
+	PrevCursor string
+	HasNext    bool
+	HasPrev    bool
+}
+
+type keysetColumn struct {
+	field *schema.Field
+	desc  bool
+}
+
+func (kc keysetColumn) column() clause.Column {
+	return clause.Column{Table: clause.CurrentTable, Name: kc.field.DBName}
+}
+
+func (gr *gormRepository[T]) FindCursor(ctx context.Context, spec Specification[T], req CursorRequest) (CursorPage[T], error) {
+	var page CursorPage[T]
+
+	if req.After != "" && req.Before != "" {
+		return page, eris.New("cursor request cannot set both After and Before")
+	}
+	limit := PageRequest{Limit: req.Limit}.normalize(gr.cfg.maxPageSize).Limit
+	backward := req.Before != ""
+	token := req.After
+	if backward {
+		token = req.Before
+	}
+
+	meta, err := gr.meta()
+	if err != nil {
+		return page, err
+	}
+
+	keys, err := gr.keysetColumns(meta, req.Sort)
+	if err != nil {
+		return page, err
+	}
+	signature := keysetSignature(keys)
+
+	db, err := gr.GetGormInstance(ctx)
+	if err != nil {
+		return page, err
+	}
+
+	query := db.Scopes(gr.filterScopes(spec)...).Scopes(gr.loadScopes(spec)...)
+
+	if token != "" {
+		values, err := gr.decodeCursor(token, signature, keys)
+		if err != nil {
+			return page, err
+		}
+		query = query.Where(keysetCondition(keys, values, backward))
+	}
+
+	for _, key := range keys {
+		query = query.Order(clause.OrderByColumn{Column: key.column(), Desc: key.desc != backward})
+	}
+
+	var items []T
+	if err = query.Limit(limit + 1).Find(&items).Error; err != nil {
+		return page, eris.Wrap(internal.ClassifyError(err), "error querying data")
+	}
+
+	hasMore := len(items) > limit
+	if hasMore {
+		items = items[:limit]
+	}
+	if backward {
+		slices.Reverse(items)
+		page.HasPrev = hasMore
+		page.HasNext = true
+	} else {
+		page.HasNext = hasMore
+		page.HasPrev = token != ""
+	}
+	page.Items = items
+
+	if len(items) == 0 {
+		return page, nil
+	}
+	if page.HasNext {
+		if page.NextCursor, err = gr.encodeCursor(ctx, items[len(items)-1], signature, keys); err != nil {
+			return page, err
+		}
+	}
+	if page.HasPrev {
+		if page.PrevCursor, err = gr.encodeCursor(ctx, items[0], signature, keys); err != nil {
+			return page, err
+		}
+	}
+
+	return page, nil
+}
+
+// keysetColumns resolves the requested sort against the schema and appends the primary key as a tiebreaker.
+// Without a requested sort, the repository's default order is used.
+func (gr *gormRepository[T]) keysetColumns(meta *modelMeta, sorts []Sort) ([]keysetColumn, error) {
+	if len(sorts) == 0 {
+		sorts = meta.defaultOrder
+	}
+	sch := meta.schema
+
+	keys := make([]keysetColumn, 0, len(sorts)+1)
+	for _, sort := range sorts {
+		field, err := internal.LookupField(sch, sort.Field)
+		if err != nil {
+			return nil, err
+		}
+		if sort.Nulls != NullsDefault {
+			return nil, eris.Errorf("cursor pagination does not support NULLS ordering on %s", sort.Field)
+		}
+		if nullableField(field) {
+			return nil, eris.Errorf("cursor pagination cannot sort by %s, which may be NULL", field.DBName)
+		}
+		keys = append(keys, keysetColumn{field, sort.Desc})
+	}
+
+	pk := sch.PrioritizedPrimaryField
+	if pk == nil {
+		if len(keys) == 0 {
+			return nil, eris.Errorf("cursor pagination of %s requires a sort or a primary key", sch.Name)
+		}
+		return keys, nil
+	}
+
+	for _, key := range keys {
+		if key.field == pk {
+			return keys, nil
+		}
+	}
+
+	desc := true
+	if len(keys) > 0 {
+		desc = keys[len(keys)-1].desc
+	}
+
+	return append(keys, keysetColumn{pk, desc}), nil
+}
+
+// nullableField reports whether field can hold NULL: a pointer or a sql.Null-like struct not marked NOT NULL.
+// Keyset comparisons are never true for NULL, so such keys would skip or repeat rows between pages.
+func nullableField(field *schema.Field) bool {
+	if field.PrimaryKey || field.NotNull {
+		return false
+	}
+
+	switch field.FieldType.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return true
+	case reflect.Struct:
+		valid, ok := field.FieldType.FieldByName("Valid")
+		return ok && valid.Type.Kind() == reflect.Bool
+	default:
+		return false
+	}
+}
+
+func keysetSignature(keys []keysetColumn) string {
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		if key.desc {
+			parts[i] = key.field.DBName + ":desc"
+		} else {
+			parts[i] = key.field.DBName + ":asc"
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// keysetCondition builds (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ..., flipping comparisons for descending keys.
+func keysetCondition(keys []keysetColumn, values []any, backward bool) clause.Expression {
+	ors := make([]clause.Expression, 0, len(keys))
+	for i, key := range keys {
+		ands := make([]clause.Expression, 0, i+1)
+		for j := range i {
+			ands = append(ands, clause.Eq{Column: keys[j].column(), Value: values[j]})
+		}
+		if key.desc != backward {
+			ands = append(ands, clause.Lt{Column: key.column(), Value: values[i]})
+		} else {
+			ands = append(ands, clause.Gt{Column: key.column(), Value: values[i]})
+		}
+		ors = append(ors, clause.And(ands...))
+	}
+	return clause.Or(ors...)
+}
+
+func (gr *gormRepository[T]) encodeCursor(ctx context.Context, model T, signature string, keys []keysetColumn) (string, error) {
+	c := internal.Cursor{Sort: signature, Values: make([]json.RawMessage, len(keys))}
+	rv := reflect.ValueOf(&model).Elem()
+
+	for i, key := range keys {
+		value, _ := key.field.ValueOf(ctx, rv)
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return "", eris.Wrap(err, "error encoding cursor")
+		}
+		c.Values[i] = raw
+	}
+
+	return internal.EncodeCursor(c, gr.cfg.cursorSecret)
+}
+
+func (gr *gormRepository[T]) decodeCursor(token, signature string, keys []keysetColumn) ([]any, error) {
+	c, err := internal.DecodeCursor(token, gr.cfg.cursorSecret)
+	if err != nil {
+		return nil, err
+	}
+	if c.Sort != signature || len(c.Values) != len(keys) {
+		return nil, eris.Wrap(ErrInvalidCursor, "cursor does not match the requested sort")
+	}
+
+	values := make([]any, len(keys))
+	for i, key := range keys {
+		ptr := reflect.New(key.field.FieldType)
+		if err = json.Unmarshal(c.Values[i], ptr.Interface()); err != nil {
+			return nil, eris.Wrap(ErrInvalidCursor, "malformed cursor value")
+		}
+		values[i] = ptr.Elem().Interface()
+	}
+
+	return values, nil
+}
@@ -0,0 +1,48 @@
+package crud
+
+import (
+	"github.com/itsLeonB/go-crud/internal"
+	"github.com/rotisserie/eris"
+)
+
+// ErrNotFound is returned when a query that requires a result matches no records.
+// It is wrapped with eris, so callers should match it with errors.Is.
+var ErrNotFound = eris.New("record not found")
+
+// ErrStaleEntity is returned when an update of a versioned model finds that the record
+// was changed by someone else since the model was loaded.
+var ErrStaleEntity = eris.New("stale entity")
+
+// Transaction propagation errors, returned by WithinTransactionOpts when the context
+// does not match the requested Propagation.
+var (
+	ErrNoTransaction     = internal.ErrNoTransaction
+	ErrTransactionExists = internal.ErrTransactionExists
+)
+
+// ErrInvalidCursor is returned by FindCursor when a cursor is malformed, tampered with,
+// or was issued for a different sort.
+var ErrInvalidCursor = internal.ErrInvalidCursor
+
+// Classified database errors. Repository and transactor errors caused by these conditions
+// match them with errors.Is, regardless of whether the database is SQLite, PostgreSQL or MySQL.
+var (
+	ErrUniqueViolation      = internal.ErrUniqueViolation
+	ErrForeignKeyViolation  = internal.ErrForeignKeyViolation
+	ErrNotNullViolation     = internal.ErrNotNullViolation
+	ErrCheckViolation       = internal.ErrCheckViolation
+	ErrSerializationFailure = internal.ErrSerializationFailure
+	ErrDeadlock             = internal.ErrDeadlock
+	ErrDatabaseBusy         = internal.ErrDatabaseBusy
+)
+
+// DBError carries the details of a classified database error.
+// Kind holds the matching sentinel (e.g. ErrUniqueViolation), and Table, Column and Constraint
+// are filled in when the driver reports them. Use errors.As to extract it from a returned error.
+type DBError = internal.DBError
+
+// ClassifyError translates a raw driver error into a *DBError when it represents a known
+// constraint violation or concurrency failure. Other errors are returned unchanged.
+func ClassifyError(err error) error {
+	return internal.ClassifyError(err)
+}
@@ -0,0 +1,328 @@
+package crud
+
+import (
+	"reflect"
+
+	"github.com/itsLeonB/go-crud/internal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// Filter is a predicate on the columns of a model, used in Specification.Filter.
+// Build filters with the constructors in this file (Eq, In, Like, And, Or, ...).
+// Field names accept either a column name or a struct field name and are validated against the model's schema.
+type Filter interface {
+	expression(fc *filterContext) (clause.Expression, error)
+}
+
+type filterContext struct {
+	db     *gorm.DB
+	schema *schema.Schema
+	table  string // Alias of schema's table inside a relation subquery; the query's own table when empty
+	depth  int    // Nesting level of relation subqueries
+}
+
+func (fc *filterContext) column(name string) (clause.Column, error) {
+	field, err := internal.LookupField(fc.schema, name)
+	if err != nil {
+		return clause.Column{}, err
+	}
+	return fc.columnOf(field), nil
+}
+
+func (fc *filterContext) columnOf(field *schema.Field) clause.Column {
+	if fc.table != "" {
+		return clause.Column{Table: fc.table, Name: field.DBName}
+	}
+	return clause.Column{Table: clause.CurrentTable, Name: field.DBName}
+}
+
+// WhereFilter returns a GORM scope that applies filter to a query on model T.
+// Invalid or unknown field names are added to the query as errors instead of being sent to the database.
+func WhereFilter[T any](filter Filter) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter == nil {
+			return db
+		}
+
+		sch, err := internal.ParseSchema(db, new(T))
+		if err != nil {
+			_ = db.AddError(err)
+			return db
+		}
+
+		expr, err := filter.expression(&filterContext{db: db, schema: sch})
+		if err != nil {
+			_ = db.AddError(err)
+			return db
+		}
+		if expr == nil {
+			return db
+		}
+
+		return db.Where(expr)
+	}
+}
+
+type compareOp int
+
+const (
+	opEq compareOp = iota
+	opNe
+	opGt
+	opGte
+	opLt
+	opLte
+)
+
+type compareFilter struct {
+	field string
+	op    compareOp
+	value any
+}
+
+func (f compareFilter) expression(fc *filterContext) (clause.Expression, error) {
+	col, err := fc.column(f.field)
+	if err != nil {
+		return nil, err
+	}
+
+	switch f.op {
+	case opNe:
+		return clause.Neq{Column: col, Value: f.value}, nil
+	case opGt:
+		return clause.Gt{Column: col, Value: f.value}, nil
+	case opGte:
+		return clause.Gte{Column: col, Value: f.value}, nil
+	case opLt:
+		return clause.Lt{Column: col, Value: f.value}, nil
+	case opLte:
+		return clause.Lte{Column: col, Value: f.value}, nil
+	default:
+		return clause.Eq{Column: col, Value: f.value}, nil
+	}
+}
+
+// Eq matches rows where field equals value. A nil value matches NULL.
+func Eq(field string, value any) Filter {
+	return compareFilter{field, opEq, value}
+}
+
+// Ne matches rows where field does not equal value. A nil value matches NOT NULL.
+func Ne(field string, value any) Filter {
+	return compareFilter{field, opNe, value}
+}
+
+// Gt matches rows where field is greater than value.
+func Gt(field string, value any) Filter {
+	return compareFilter{field, opGt, value}
+}
+
+// Gte matches rows where field is greater than or equal to value.
+func Gte(field string, value any) Filter {
+	return compareFilter{field, opGte, value}
+}
+
+// Lt matches rows where field is less than value.
+func Lt(field string, value any) Filter {
+	return compareFilter{field, opLt, value}
+}
+
+// Lte matches rows where field is less than or equal to value.
+func Lte(field string, value any) Filter {
+	return compareFilter{field, opLte, value}
+}
+
+type inFilter struct {
+	field  string
+	values []any
+	negate bool
+}
+
+func (f inFilter) expression(fc *filterContext) (clause.Expression, error) {
+	col, err := fc.column(f.field)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.negate {
+		if len(f.values) == 0 {
+			// Not nil, which means no predicate and would be dropped or negated wrongly by Or and Not
+			return matchAll, nil
+		}
+		return clause.Not(clause.IN{Column: col, Values: f.values}), nil
+	}
+
+	return clause.IN{Column: col, Values: f.values}, nil
+}
+
+// In matches rows where field is one of values. A single slice argument is expanded,
+// so In("status", statuses) and In("status", "a", "b") are equivalent. An empty list matches nothing.
+func In(field string, values ...any) Filter {
+	return inFilter{field, flattenValues(values), false}
+}
+
+// NotIn matches rows where field is none of values. An empty list matches everything.
+func NotIn(field string, values ...any) Filter {
+	return inFilter{field, flattenValues(values), true}
+}
+
+func flattenValues(values []any) []any {
+	if len(values) != 1 {
+		return values
+	}
+
+	rv := reflect.ValueOf(values[0])
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return values
+	}
+	if rv.Type().Elem().Kind() == reflect.Uint8 {
+		return values // []byte is a single value
+	}
+
+	flat := make([]any, rv.Len())
+	for i := range flat {
+		flat[i] = rv.Index(i).Interface()
+	}
+	return flat
+}
+
+type likeFilter struct {
+	field           string
+	pattern         string
+	caseInsensitive bool
+}
+
+func (f likeFilter) expression(fc *filterContext) (clause.Expression, error) {
+	col, err := fc.column(f.field)
+	if err != nil {
+		return nil, err
+	}
+
+	if !f.caseInsensitive {
+		return clause.Like{Column: col, Value: f.pattern}, nil
+	}
+	if fc.db.Dialector.Name() == "postgres" {
+		return clause.Expr{SQL: "? ILIKE ?", Vars: []any{col, f.pattern}}, nil
+	}
+
+	return clause.Expr{SQL: "LOWER(?) LIKE LOWER(?)", Vars: []any{col, f.pattern}}, nil
+}
+
+// Like matches rows where field matches the SQL LIKE pattern.
+func Like(field, pattern string) Filter {
+	return likeFilter{field, pattern, false}
+}
+
+// ILike matches rows where field matches the SQL LIKE pattern case-insensitively.
+// It uses ILIKE on PostgreSQL and LOWER(field) LIKE LOWER(pattern) elsewhere.
+func ILike(field, pattern string) Filter {
+	return likeFilter{field, pattern, true}
+}
+
+// IsNull matches rows where field is NULL.
+func IsNull(field string) Filter {
+	return compareFilter{field, opEq, nil}
+}
+
+// NotNull matches rows where field is not NULL.
+func NotNull(field string) Filter {
+	return compareFilter{field, opNe, nil}
+}
+
+type betweenFilter struct {
+	field string
+	low   any
+	high  any
+}
+
+func (f betweenFilter) expression(fc *filterContext) (clause.Expression, error) {
+	col, err := fc.column(f.field)
+	if err != nil {
+		return nil, err
+	}
+	return clause.Expr{SQL: "? BETWEEN ? AND ?", Vars: []any{col, f.low, f.high}}, nil
+}
+
+// Between matches rows where field is within the inclusive range [low, high].
+func Between(field string, low, high any) Filter {
+	return betweenFilter{field, low, high}
+}
+
+var (
+	matchAll  = clause.Expr{SQL: "1 = 1"}
+	matchNone = clause.Expr{SQL: "1 = 0"}
+)
+
+// isMatchAll reports whether expr is the always-true predicate of a filter that restricts nothing.
+func isMatchAll(expr clause.Expression) bool {
+	e, ok := expr.(clause.Expr)
+	return ok && e.SQL == matchAll.SQL && len(e.Vars) == 0
+}
+
+type groupFilter struct {
+	filters []Filter
+	or      bool
+}
+
+func (f groupFilter) expression(fc *filterContext) (clause.Expression, error) {
+	exprs := make([]clause.Expression, 0, len(f.filters))
+	for _, filter := range f.filters {
+		if filter == nil {
+			continue
+		}
+		expr, err := filter.expression(fc)
+		if err != nil {
+			return nil, err
+		}
+		if expr != nil {
+			exprs = append(exprs, expr)
+		}
+	}
+
+	switch {
+	case len(exprs) == 0:
+		return nil, nil
+	case len(exprs) == 1:
+		return exprs[0], nil
+	case f.or:
+		return clause.Or(exprs...), nil
+	default:
+		return clause.And(exprs...), nil
+	}
+}
+
+// And matches rows that satisfy every filter. Nil filters are ignored.
+func And(filters ...Filter) Filter {
+	return groupFilter{filters, false}
+}
+
+// Or matches rows that satisfy at least one filter. Nil filters are ignored.
+func Or(filters ...Filter) Filter {
+	return groupFilter{filters, true}
+}
+
+type notFilter struct {
+	filter Filter
+}
+
+func (f notFilter) expression(fc *filterContext) (clause.Expression, error) {
+	if f.filter == nil {
+		return nil, nil
+	}
+	expr, err := f.filter.expression(fc)
+	if err != nil {
+		return nil, err
+	}
+	if expr == nil {
+		// The filter has no predicate, e.g. an empty And or Or, so it matches every row
+		return matchNone, nil
+	}
+	return clause.Not(expr), nil
+}
+
+// Not matches rows that do not satisfy filter. Not of a filter without predicates, such as Or(), matches nothing.
+func Not(filter Filter) Filter {
+	return notFilter{filter}
+}
@@ -2,8 +2,13 @@ package crud
 
 import (
 	"context"
+	"errors"
+	"iter"
 	"reflect"
+	"slices"
+	"sync"
 
+	"github.com/itsLeonB/go-crud/internal"
 	"github.com/rotisserie/eris"
 	"gorm.io/gorm"
 )
@@ -17,42 +22,141 @@ type Repository[T any] interface {
 	// FindAll retrieves multiple records based on the specification.
 	FindAll(ctx context.Context, spec Specification[T]) ([]T, error)
 	// FindFirst retrieves the first record matching the specification.
+	// It returns a zero value when nothing matches, unless the repository was created WithStrictFindFirst.
 	FindFirst(ctx context.Context, spec Specification[T]) (T, error)
+	// FindOne retrieves the first record matching the specification, returning ErrNotFound when nothing matches.
+	FindOne(ctx context.Context, spec Specification[T]) (T, error)
 	// Update modifies an existing record in the database.
+	// Versioned models are only written when their version still matches, otherwise ErrStaleEntity is returned.
 	Update(ctx context.Context, model T) (T, error)
+	// UpdateFields writes only the named fields of model, identified by its primary key, and bumps its update timestamp.
+	// Fields accept column or struct field names. It returns ErrNotFound when no record was updated.
+	// Versioned models are checked and incremented like in Update.
+	// The returned model is reloaded from the database when the repository was created WithRefreshOnUpdate.
+	UpdateFields(ctx context.Context, model T, fields ...string) (T, error)
+	// UpdateMap writes the columns in changes to the record with the given primary key, bumps its update timestamp
+	// and returns the refreshed record. It returns ErrNotFound when no record was updated.
+	UpdateMap(ctx context.Context, id any, changes map[string]any) (T, error)
 	// Delete removes a record from the database (hard delete).
 	Delete(ctx context.Context, model T) error
+	// UpdateWhere writes changes to every record matching the specification's filters in a single statement
+	// and returns the number of records updated. It refuses to run without conditions unless AllowFullTable is passed.
+	UpdateWhere(ctx context.Context, spec Specification[T], changes map[string]any, opts ...BulkOption) (int64, error)
+	// DeleteWhere removes every record matching the specification's filters in a single statement
+	// and returns the number of records removed. Models with a deleted column are soft deleted unless HardDelete is passed.
+	// It refuses to run without conditions unless AllowFullTable is passed.
+	DeleteWhere(ctx context.Context, spec Specification[T], opts ...BulkOption) (int64, error)
+	// Upsert inserts model, or resolves a conflict on opts.ConflictColumns as described by opts.
+	Upsert(ctx context.Context, model T, opts UpsertOptions) (T, error)
+	// UpsertMany inserts models in a single statement, resolving conflicts as described by opts.
+	UpsertMany(ctx context.Context, models []T, opts UpsertOptions) ([]T, error)
 	// InsertMany creates multiple records in a single database operation.
 	InsertMany(ctx context.Context, models []T) ([]T, error)
 	// DeleteMany removes multiple records in a single database operation (hard delete).
 	DeleteMany(ctx context.Context, models []T) error
+	// SoftDelete marks a record as deleted by setting its deleted column.
+	SoftDelete(ctx context.Context, model T) error
+	// SoftDeleteMany marks multiple records as deleted in a single database operation.
+	SoftDeleteMany(ctx context.Context, models []T) error
+	// Restore clears the deleted column of a soft-deleted record.
+	Restore(ctx context.Context, model T) error
+	// RestoreMany clears the deleted column of multiple records in a single database operation.
+	RestoreMany(ctx context.Context, models []T) error
 	// SaveMany saves multiple records in a single database operation.
+	// Versioned models are saved one by one within a transaction, failing with ErrStaleEntity on any version mismatch.
 	SaveMany(ctx context.Context, models []T) ([]T, error)
+	// FindPage retrieves one page of records matching the specification, along with the total count.
+	// The page request replaces the specification's Limit and Offset.
+	FindPage(ctx context.Context, spec Specification[T], req PageRequest) (Page[T], error)
+	// FindCursor retrieves one page of records using keyset pagination, which stays stable while data changes.
+	// Sort fields must not hold NULL; pointer and sql.Null fields without a NOT NULL constraint are rejected.
+	FindCursor(ctx context.Context, spec Specification[T], req CursorRequest) (CursorPage[T], error)
+	// Iterate streams the records matching the specification one row at a time instead of loading them all.
+	// Iteration stops at the first error, which is yielded with a zero value. PreloadRelations is not applied.
+	// The rows stay open until the loop ends, holding the connection. Within a transaction, do not query the same
+	// transaction from the loop body: PostgreSQL (pgx) fails with "conn busy" and MySQL with "commands out of sync".
+	// Use FindInBatches to query or write while walking the records of a transaction.
+	Iterate(ctx context.Context, spec Specification[T]) iter.Seq2[T, error]
+	// FindInBatches loads the records matching the specification in batches of batchSize, walking the primary key
+	// in ascending order, and calls fn with each batch. It stops when fn returns an error, which is returned as is.
+	// OrderBy, Limit and Offset are ignored.
+	FindInBatches(ctx context.Context, spec Specification[T], batchSize int, fn func(batch []T) error) error
+	// QueryBySpec returns a transaction-aware query on T with the specification applied,
+	// for projections and other reads the repository does not cover. See FindAllAs and Pluck.
+	QueryBySpec(ctx context.Context, spec Specification[T]) (*gorm.DB, error)
+	// Count returns the number of records matching the specification's filters.
+	// OrderBy, Limit and Offset are ignored.
+	Count(ctx context.Context, spec Specification[T]) (int64, error)
+	// Exists reports whether any record matches the specification's filters.
+	Exists(ctx context.Context, spec Specification[T]) (bool, error)
+	// Aggregate applies fn to field over the records matching the specification's filters and scans the result into dest.
+	// Prefer the typed helpers Sum, Avg, Min and Max.
+	Aggregate(ctx context.Context, spec Specification[T], fn AggregateFunc, field string, dest any) error
 	// GetGormInstance returns the appropriate GORM DB instance (transaction-aware).
 	GetGormInstance(ctx context.Context) (*gorm.DB, error)
 }
 
+// KeyedRepository extends Repository with operations addressed by primary key.
+// The primary key column is resolved from the model's GORM schema, and ID must match its Go type.
+// Composite primary keys are not supported.
+type KeyedRepository[T any, ID comparable] interface {
+	Repository[T]
+	// FindByID retrieves the record with the given primary key, returning ErrNotFound when it does not exist.
+	FindByID(ctx context.Context, id ID) (T, error)
+	// FindByIDs retrieves the records with the given primary keys in the order of ids, skipping missing ones.
+	// Large inputs are queried in chunks to respect driver parameter limits.
+	FindByIDs(ctx context.Context, ids []ID) ([]T, error)
+	// ExistsByID reports whether a record with the given primary key exists.
+	ExistsByID(ctx context.Context, id ID) (bool, error)
+	// DeleteByID removes the record with the given primary key (hard delete), returning ErrNotFound when it does not exist.
+	DeleteByID(ctx context.Context, id ID) error
+}
+
 // Specification defines query parameters for database operations.
 // It includes the model for WHERE conditions, relations to preload, and locking options.
 type Specification[T any] struct {
-	Model            T        // Model with fields set for WHERE conditions
-	PreloadRelations []string // Relations to eager load
-	ForUpdate        bool     // Whether to use SELECT ... FOR UPDATE
-	DeletedFilter    DeletedFilter
+	Model            T             // Model with fields set for WHERE conditions
+	Fields           []string      // Fields of Model to match even when they hold a zero value
+	Filter           Filter        // Additional predicates, combined with Model using AND
+	OrderBy          []Sort        // Sort order; the repository default order is used when empty
+	Limit            int           // Maximum number of records to return; 0 means no limit
+	Offset           int           // Number of records to skip
+	Select           []string      // Columns to load; all columns when empty. Ignored by FindCursor and FindInBatches
+	Omit             []string      // Columns to leave out. Ignored by FindCursor and FindInBatches
+	PreloadRelations []string      // Relations to eager load
+	Preloads         []Preload     // Relations to eager load with conditions, order, limits and nested relations
+	ForUpdate        bool          // Whether to use SELECT ... FOR UPDATE; shorthand for Lock{Strength: LockForUpdate}
+	Lock             Lock          // Row locking mode; takes precedence over ForUpdate when set
+	DeletedFilter    DeletedFilter // Soft delete visibility; unset uses the repository default
 }
 
 // NewRepository creates a new CRUD repository implementation using GORM.
 // The repository provides transaction-aware database operations for the specified entity type T.
-func NewRepository[T any](db *gorm.DB) Repository[T] {
+// Optional behavior can be configured with RepositoryOption values.
+func NewRepository[T any](db *gorm.DB, opts ...RepositoryOption) Repository[T] {
+	return newGormRepository[T](db, opts)
+}
+
+// NewKeyedRepository creates a new CRUD repository implementation using GORM with primary key operations.
+// ID must be the Go type of T's primary key field.
+func NewKeyedRepository[T any, ID comparable](db *gorm.DB, opts ...RepositoryOption) KeyedRepository[T, ID] {
+	return &gormKeyedRepository[T, ID]{newGormRepository[T](db, opts)}
+}
+
+func newGormRepository[T any](db *gorm.DB, opts []RepositoryOption) *gormRepository[T] {
 	var zero T
 	if typ := reflect.TypeOf(zero); typ != nil && typ.Kind() == reflect.Ptr {
 		panic("Repository does not support pointer types for T")
 	}
-	return &gormRepository[T]{db}
+	return &gormRepository[T]{db: db, cfg: newRepositoryConfig(opts)}
 }
 
 type gormRepository[T any] struct {
-	db *gorm.DB
+	db        *gorm.DB
+	cfg       repositoryConfig
+	metaOnce  sync.Once
+	metaCache *modelMeta
+	metaErr   error
 }
 
 func (gr *gormRepository[T]) Insert(ctx context.Context, model T) (T, error) {
@@ -68,7 +172,7 @@ func (gr *gormRepository[T]) Insert(ctx context.Context, model T) (T, error) {
 	}
 
 	if err = db.Create(&model).Error; err != nil {
-		return zero, eris.Wrap(err, "error inserting data")
+		return zero, eris.Wrap(internal.ClassifyError(err), "error inserting data")
 	}
 
 	return model, nil
@@ -82,24 +186,28 @@ func (gr *gormRepository[T]) FindAll(ctx context.Context, spec Specification[T])
 		return nil, err
 	}
 
-	err = db.Scopes(
-		WhereBySpec(spec.Model),
-		DefaultOrder(),
-		PreloadRelations(spec.PreloadRelations),
-		ForUpdate(spec.ForUpdate),
-		spec.DeletedFilter.WhereDeleted(),
-	).
+	err = db.Scopes(gr.queryScopes(spec)...).
+		Scopes(gr.rangeScope(spec)).
 		Find(&models).
 		Error
 
 	if err != nil {
-		return nil, eris.Wrap(err, "error querying data")
+		return nil, eris.Wrap(internal.ClassifyError(err), "error querying data")
 	}
 
 	return models, nil
 }
 
 func (gr *gormRepository[T]) FindFirst(ctx context.Context, spec Specification[T]) (T, error) {
+	model, err := gr.FindOne(ctx, spec)
+	if err != nil && !gr.cfg.strictFindFirst && errors.Is(err, ErrNotFound) {
+		return model, nil
+	}
+
+	return model, err
+}
+
+func (gr *gormRepository[T]) FindOne(ctx context.Context, spec Specification[T]) (T, error) {
 	var model T
 
 	db, err := gr.GetGormInstance(ctx)
@@ -107,26 +215,57 @@ func (gr *gormRepository[T]) FindFirst(ctx context.Context, spec Specification[T
 		return model, err
 	}
 
-	err = db.Scopes(
-		WhereBySpec(spec.Model),
-		DefaultOrder(),
-		PreloadRelations(spec.PreloadRelations),
-		ForUpdate(spec.ForUpdate),
-		spec.DeletedFilter.WhereDeleted(),
-	).
-		First(&model).
+	// Take rather than First: First orders by primary key ahead of the specification's order.
+	err = db.Scopes(gr.queryScopes(spec)...).
+		Offset(spec.Offset).
+		Take(&model).
 		Error
 
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return model, nil
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model, eris.Wrap(ErrNotFound, "error querying data")
 		}
-		return model, eris.Wrap(err, "error querying data")
+		return model, eris.Wrap(internal.ClassifyError(err), "error querying data")
 	}
 
 	return model, nil
 }
 
+func (gr *gormRepository[T]) FindPage(ctx context.Context, spec Specification[T], req PageRequest) (Page[T], error) {
+	req = req.normalize(gr.cfg.maxPageSize)
+	page := Page[T]{Page: req.Page, Limit: req.Limit, Items: []T{}}
+
+	db, err := gr.GetGormInstance(ctx)
+	if err != nil {
+		return page, err
+	}
+
+	err = db.Model(new(T)).
+		Scopes(gr.filterScopes(spec)...).
+		Count(&page.Total).
+		Error
+
+	if err != nil {
+		return page, eris.Wrap(internal.ClassifyError(err), "error counting data")
+	}
+
+	if page.Total > 0 {
+		err = db.Scopes(gr.queryScopes(spec)...).
+			Scopes(Paginate(req.Page, req.Limit)).
+			Find(&page.Items).
+			Error
+
+		if err != nil {
+			return page, eris.Wrap(internal.ClassifyError(err), "error querying data")
+		}
+	}
+
+	page.TotalPages = int((page.Total + int64(req.Limit) - 1) / int64(req.Limit))
+	page.HasNext = req.Page < page.TotalPages
+
+	return page, nil
+}
+
 func (gr *gormRepository[T]) Update(ctx context.Context, model T) (T, error) {
 	var zero T
 
@@ -139,8 +278,16 @@ func (gr *gormRepository[T]) Update(ctx context.Context, model T) (T, error) {
 		return zero, err
 	}
 
+	meta, err := gr.meta()
+	if err != nil {
+		return zero, err
+	}
+	if meta.versionColumn != "" {
+		return gr.updateVersioned(ctx, db, meta, model)
+	}
+
 	if err = db.Save(&model).Error; err != nil {
-		return zero, eris.Wrap(err, "error updating data")
+		return zero, eris.Wrap(internal.ClassifyError(err), "error updating data")
 	}
 
 	return model, nil
@@ -157,7 +304,7 @@ func (gr *gormRepository[T]) Delete(ctx context.Context, model T) error {
 	}
 
 	if err = db.Unscoped().Delete(&model).Error; err != nil {
-		return eris.Wrap(err, "error deleting data")
+		return eris.Wrap(internal.ClassifyError(err), "error deleting data")
 	}
 
 	return nil
@@ -174,7 +321,7 @@ func (gr *gormRepository[T]) InsertMany(ctx context.Context, models []T) ([]T, e
 	}
 
 	if err = db.Create(&models).Error; err != nil {
-		return nil, eris.Wrap(err, "error batch inserting data")
+		return nil, eris.Wrap(internal.ClassifyError(err), "error batch inserting data")
 	}
 
 	return models, nil
@@ -191,7 +338,7 @@ func (gr *gormRepository[T]) DeleteMany(ctx context.Context, models []T) error {
 	}
 
 	if err = db.Unscoped().Delete(&models).Error; err != nil {
-		return eris.Wrap(err, "error batch deleting data")
+		return eris.Wrap(internal.ClassifyError(err), "error batch deleting data")
 	}
 
 	return nil
@@ -207,13 +354,130 @@ func (gr *gormRepository[T]) SaveMany(ctx context.Context, models []T) ([]T, err
 		return nil, err
 	}
 
+	meta, err := gr.meta()
+	if err != nil {
+		return nil, err
+	}
+	if meta.versionColumn != "" {
+		return gr.saveManyVersioned(ctx, db, meta, models)
+	}
+
 	if err = db.Save(&models).Error; err != nil {
-		return nil, eris.Wrap(err, "error saving many data")
+		return nil, eris.Wrap(internal.ClassifyError(err), "error saving many data")
 	}
 
 	return models, nil
 }
 
+// filterScopes returns the scopes that restrict which rows a specification matches.
+func (gr *gormRepository[T]) filterScopes(spec Specification[T]) []func(*gorm.DB) *gorm.DB {
+	return []func(*gorm.DB) *gorm.DB{
+		WhereBySpec(spec.Model, spec.Fields...),
+		WhereFilter[T](spec.Filter),
+		gr.deletedScope(spec.DeletedFilter),
+	}
+}
+
+// deletedScope applies filter, or the repository's default filter when it is unset, to the model's deleted column.
+func (gr *gormRepository[T]) deletedScope(filter DeletedFilter) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		meta, err := gr.meta()
+		if err != nil {
+			_ = db.AddError(err)
+			return db
+		}
+
+		if filter.filterType == nil {
+			if meta.deletedColumn == "" {
+				return db
+			}
+			filter = ExcludeDeleted
+			if gr.cfg.deletedFilter != nil {
+				filter = *gr.cfg.deletedFilter
+			}
+		}
+		if filter.filterType == nil {
+			return db
+		}
+		if meta.deletedColumn == "" {
+			// Every record of a model without soft delete is included, so generic callers may always ask for it
+			if _, include := filter.filterType.(internal.IncludeDeleted); include {
+				return db
+			}
+			_ = db.AddError(eris.Errorf("%s has no deleted column to filter on", meta.schema.Name))
+			return db
+		}
+
+		// Let the filter, rather than GORM's implicit soft delete clause, decide what is visible.
+		if meta.gormDeletedAt {
+			db = db.Unscoped()
+		}
+
+		return filter.WhereDeletedColumn(meta.deletedColumn)(db)
+	}
+}
+
+// loadScopes returns the scopes that control how matched rows are loaded.
+func (gr *gormRepository[T]) loadScopes(spec Specification[T]) []func(*gorm.DB) *gorm.DB {
+	return []func(*gorm.DB) *gorm.DB{
+		PreloadRelations(spec.PreloadRelations),
+		PreloadWith[T](spec.Preloads...),
+		gr.lockScope(spec),
+	}
+}
+
+// orderScope returns the specification's sort, falling back to the repository's default order.
+// Struct field names are translated to their column names.
+func (gr *gormRepository[T]) orderScope(spec Specification[T]) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		meta, err := gr.meta()
+		if err != nil {
+			_ = db.AddError(err)
+			return db
+		}
+
+		if len(spec.OrderBy) == 0 {
+			return SortBy(meta.defaultOrder...)(db)
+		}
+
+		sorts := slices.Clone(spec.OrderBy)
+		for i, sort := range sorts {
+			if field := meta.schema.LookUpField(sort.Field); field != nil && field.DBName != "" {
+				sorts[i].Field = field.DBName
+			}
+		}
+
+		return SortBy(sorts...)(db)
+	}
+}
+
+// lockScope applies the specification's Lock, falling back to ForUpdate when it is unset.
+func (gr *gormRepository[T]) lockScope(spec Specification[T]) func(*gorm.DB) *gorm.DB {
+	if spec.Lock != (Lock{}) {
+		return Locking(spec.Lock)
+	}
+	return ForUpdate(spec.ForUpdate)
+}
+
+// rangeScope applies the specification's limit and offset.
+func (gr *gormRepository[T]) rangeScope(spec Specification[T]) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if spec.Limit > 0 {
+			db = db.Limit(spec.Limit)
+		}
+		if spec.Offset > 0 {
+			db = db.Offset(spec.Offset)
+		}
+		return db
+	}
+}
+
+// queryScopes returns the filter, ordering, column selection and load scopes for row queries.
+func (gr *gormRepository[T]) queryScopes(spec Specification[T]) []func(*gorm.DB) *gorm.DB {
+	scopes := append(gr.filterScopes(spec), gr.orderScope(spec), gr.selectScope(spec))
+	return append(scopes, gr.loadScopes(spec)...)
+}
+
 func (gr *gormRepository[T]) checkZeroValue(model T) error {
 	if reflect.DeepEqual(model, *new(T)) {
 		return eris.New("model cannot be zero value")
@@ -11,6 +11,7 @@ package crud
 
 import (
 	context "context"
+	iter "iter"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -41,6 +42,35 @@ func (m *MockRepository[T]) EXPECT() *MockRepositoryMockRecorder[T] {
 	return m.recorder
 }
 
+// Aggregate mocks base method.
+func (m *MockRepository[T]) Aggregate(ctx context.Context, spec Specification[T], fn AggregateFunc, field string, dest any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Aggregate", ctx, spec, fn, field, dest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Aggregate indicates an expected call of Aggregate.
+func (mr *MockRepositoryMockRecorder[T]) Aggregate(ctx, spec, fn, field, dest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Aggregate", reflect.TypeOf((*MockRepository[T])(nil).Aggregate), ctx, spec, fn, field, dest)
+}
+
+// Count mocks base method.
+func (m *MockRepository[T]) Count(ctx context.Context, spec Specification[T]) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, spec)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockRepositoryMockRecorder[T]) Count(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockRepository[T])(nil).Count), ctx, spec)
+}
+
 // Delete mocks base method.
 func (m *MockRepository[T]) Delete(ctx context.Context, model T) error {
 	m.ctrl.T.Helper()
@@ -69,6 +99,41 @@ func (mr *MockRepositoryMockRecorder[T]) DeleteMany(ctx, models any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMany", reflect.TypeOf((*MockRepository[T])(nil).DeleteMany), ctx, models)
 }
 
+// DeleteWhere mocks base method.
+func (m *MockRepository[T]) DeleteWhere(ctx context.Context, spec Specification[T], opts ...BulkOption) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, spec}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteWhere", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWhere indicates an expected call of DeleteWhere.
+func (mr *MockRepositoryMockRecorder[T]) DeleteWhere(ctx, spec any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, spec}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWhere", reflect.TypeOf((*MockRepository[T])(nil).DeleteWhere), varargs...)
+}
+
+// Exists mocks base method.
+func (m *MockRepository[T]) Exists(ctx context.Context, spec Specification[T]) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", ctx, spec)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockRepositoryMockRecorder[T]) Exists(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockRepository[T])(nil).Exists), ctx, spec)
+}
+
 // FindAll mocks base method.
 func (m *MockRepository[T]) FindAll(ctx context.Context, spec Specification[T]) ([]T, error) {
 	m.ctrl.T.Helper()
@@ -84,6 +149,21 @@ func (mr *MockRepositoryMockRecorder[T]) FindAll(ctx, spec any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockRepository[T])(nil).FindAll), ctx, spec)
 }
 
+// FindCursor mocks base method.
+func (m *MockRepository[T]) FindCursor(ctx context.Context, spec Specification[T], req CursorRequest) (CursorPage[T], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCursor", ctx, spec, req)
+	ret0, _ := ret[0].(CursorPage[T])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCursor indicates an expected call of FindCursor.
+func (mr *MockRepositoryMockRecorder[T]) FindCursor(ctx, spec, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCursor", reflect.TypeOf((*MockRepository[T])(nil).FindCursor), ctx, spec, req)
+}
+
 // FindFirst mocks base method.
 func (m *MockRepository[T]) FindFirst(ctx context.Context, spec Specification[T]) (T, error) {
 	m.ctrl.T.Helper()
@@ -99,6 +179,50 @@ func (mr *MockRepositoryMockRecorder[T]) FindFirst(ctx, spec any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFirst", reflect.TypeOf((*MockRepository[T])(nil).FindFirst), ctx, spec)
 }
 
+// FindInBatches mocks base method.
+func (m *MockRepository[T]) FindInBatches(ctx context.Context, spec Specification[T], batchSize int, fn func([]T) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindInBatches", ctx, spec, batchSize, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindInBatches indicates an expected call of FindInBatches.
+func (mr *MockRepositoryMockRecorder[T]) FindInBatches(ctx, spec, batchSize, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindInBatches", reflect.TypeOf((*MockRepository[T])(nil).FindInBatches), ctx, spec, batchSize, fn)
+}
+
+// FindOne mocks base method.
+func (m *MockRepository[T]) FindOne(ctx context.Context, spec Specification[T]) (T, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOne", ctx, spec)
+	ret0, _ := ret[0].(T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOne indicates an expected call of FindOne.
+func (mr *MockRepositoryMockRecorder[T]) FindOne(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOne", reflect.TypeOf((*MockRepository[T])(nil).FindOne), ctx, spec)
+}
+
+// FindPage mocks base method.
+func (m *MockRepository[T]) FindPage(ctx context.Context, spec Specification[T], req PageRequest) (Page[T], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPage", ctx, spec, req)
+	ret0, _ := ret[0].(Page[T])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPage indicates an expected call of FindPage.
+func (mr *MockRepositoryMockRecorder[T]) FindPage(ctx, spec, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPage", reflect.TypeOf((*MockRepository[T])(nil).FindPage), ctx, spec, req)
+}
+
 // GetGormInstance mocks base method.
 func (m *MockRepository[T]) GetGormInstance(ctx context.Context) (*gorm.DB, error) {
 	m.ctrl.T.Helper()
@@ -144,6 +268,63 @@ func (mr *MockRepositoryMockRecorder[T]) InsertMany(ctx, models any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertMany", reflect.TypeOf((*MockRepository[T])(nil).InsertMany), ctx, models)
 }
 
+// Iterate mocks base method.
+func (m *MockRepository[T]) Iterate(ctx context.Context, spec Specification[T]) iter.Seq2[T, error] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Iterate", ctx, spec)
+	ret0, _ := ret[0].(iter.Seq2[T, error])
+	return ret0
+}
+
+// Iterate indicates an expected call of Iterate.
+func (mr *MockRepositoryMockRecorder[T]) Iterate(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Iterate", reflect.TypeOf((*MockRepository[T])(nil).Iterate), ctx, spec)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockRepository[T]) QueryBySpec(ctx context.Context, spec Specification[T]) (*gorm.DB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].(*gorm.DB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockRepositoryMockRecorder[T]) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockRepository[T])(nil).QueryBySpec), ctx, spec)
+}
+
+// Restore mocks base method.
+func (m *MockRepository[T]) Restore(ctx context.Context, model T) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockRepositoryMockRecorder[T]) Restore(ctx, model any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockRepository[T])(nil).Restore), ctx, model)
+}
+
+// RestoreMany mocks base method.
+func (m *MockRepository[T]) RestoreMany(ctx context.Context, models []T) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreMany", ctx, models)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreMany indicates an expected call of RestoreMany.
+func (mr *MockRepositoryMockRecorder[T]) RestoreMany(ctx, models any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreMany", reflect.TypeOf((*MockRepository[T])(nil).RestoreMany), ctx, models)
+}
+
 // SaveMany mocks base method.
 func (m *MockRepository[T]) SaveMany(ctx context.Context, models []T) ([]T, error) {
 	m.ctrl.T.Helper()
@@ -159,6 +340,34 @@ func (mr *MockRepositoryMockRecorder[T]) SaveMany(ctx, models any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMany", reflect.TypeOf((*MockRepository[T])(nil).SaveMany), ctx, models)
 }
 
+// SoftDelete mocks base method.
+func (m *MockRepository[T]) SoftDelete(ctx context.Context, model T) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDelete", ctx, model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SoftDelete indicates an expected call of SoftDelete.
+func (mr *MockRepositoryMockRecorder[T]) SoftDelete(ctx, model any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDelete", reflect.TypeOf((*MockRepository[T])(nil).SoftDelete), ctx, model)
+}
+
+// SoftDeleteMany mocks base method.
+func (m *MockRepository[T]) SoftDeleteMany(ctx context.Context, models []T) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDeleteMany", ctx, models)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SoftDeleteMany indicates an expected call of SoftDeleteMany.
+func (mr *MockRepositoryMockRecorder[T]) SoftDeleteMany(ctx, models any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDeleteMany", reflect.TypeOf((*MockRepository[T])(nil).SoftDeleteMany), ctx, models)
+}
+
 // Update mocks base method.
 func (m *MockRepository[T]) Update(ctx context.Context, model T) (T, error) {
 	m.ctrl.T.Helper()
@@ -173,3 +382,597 @@ func (mr *MockRepositoryMockRecorder[T]) Update(ctx, model any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository[T])(nil).Update), ctx, model)
 }
+
+// UpdateFields mocks base method.
+func (m *MockRepository[T]) UpdateFields(ctx context.Context, model T, fields ...string) (T, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, model}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateFields", varargs...)
+	ret0, _ := ret[0].(T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFields indicates an expected call of UpdateFields.
+func (mr *MockRepositoryMockRecorder[T]) UpdateFields(ctx, model any, fields ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, model}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFields", reflect.TypeOf((*MockRepository[T])(nil).UpdateFields), varargs...)
+}
+
+// UpdateMap mocks base method.
+func (m *MockRepository[T]) UpdateMap(ctx context.Context, id any, changes map[string]any) (T, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMap", ctx, id, changes)
+	ret0, _ := ret[0].(T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMap indicates an expected call of UpdateMap.
+func (mr *MockRepositoryMockRecorder[T]) UpdateMap(ctx, id, changes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMap", reflect.TypeOf((*MockRepository[T])(nil).UpdateMap), ctx, id, changes)
+}
+
+// UpdateWhere mocks base method.
+func (m *MockRepository[T]) UpdateWhere(ctx context.Context, spec Specification[T], changes map[string]any, opts ...BulkOption) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, spec, changes}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateWhere", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWhere indicates an expected call of UpdateWhere.
+func (mr *MockRepositoryMockRecorder[T]) UpdateWhere(ctx, spec, changes any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, spec, changes}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWhere", reflect.TypeOf((*MockRepository[T])(nil).UpdateWhere), varargs...)
+}
+
+// Upsert mocks base method.
+func (m *MockRepository[T]) Upsert(ctx context.Context, model T, opts UpsertOptions) (T, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, model, opts)
+	ret0, _ := ret[0].(T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockRepositoryMockRecorder[T]) Upsert(ctx, model, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockRepository[T])(nil).Upsert), ctx, model, opts)
+}
+
+// UpsertMany mocks base method.
+func (m *MockRepository[T]) UpsertMany(ctx context.Context, models []T, opts UpsertOptions) ([]T, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertMany", ctx, models, opts)
+	ret0, _ := ret[0].([]T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertMany indicates an expected call of UpsertMany.
+func (mr *MockRepositoryMockRecorder[T]) UpsertMany(ctx, models, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertMany", reflect.TypeOf((*MockRepository[T])(nil).UpsertMany), ctx, models, opts)
+}
+
+// MockKeyedRepository is a mock of KeyedRepository interface.
+type MockKeyedRepository[T any, ID comparable] struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyedRepositoryMockRecorder[T, ID]
+	isgomock struct{}
+}
+
+// MockKeyedRepositoryMockRecorder is the mock recorder for MockKeyedRepository.
+type MockKeyedRepositoryMockRecorder[T any, ID comparable] struct {
+	mock *MockKeyedRepository[T, ID]
+}
+
+// NewMockKeyedRepository creates a new mock instance.
+func NewMockKeyedRepository[T any, ID comparable](ctrl *gomock.Controller) *MockKeyedRepository[T, ID] {
+	mock := &MockKeyedRepository[T, ID]{ctrl: ctrl}
+	mock.recorder = &MockKeyedRepositoryMockRecorder[T, ID]{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyedRepository[T, ID]) EXPECT() *MockKeyedRepositoryMockRecorder[T, ID] {
+	return m.recorder
+}
+
+// Aggregate mocks base method.
+func (m *MockKeyedRepository[T, ID]) Aggregate(ctx context.Context, spec Specification[T], fn AggregateFunc, field string, dest any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Aggregate", ctx, spec, fn, field, dest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Aggregate indicates an expected call of Aggregate.
+func (mr *MockKeyedRepositoryMockRecorder[T, ID]) Aggregate(ctx, spec, fn, field, dest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Aggregate", reflect.TypeOf((*MockKeyedRepository[T, ID])(nil).Aggregate), ctx, spec, fn, field, dest)
+}
+
+// Count mocks base method.
+func (m *MockKeyedRepository[T, ID]) Count(ctx context.Context, spec Specification[T]) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, spec)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockKeyedRepositoryMockRecorder[T, ID]) Count(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockKeyedRepository[T, ID])(nil).Count), ctx, spec)
+}
+
+// Delete mocks base method.
+func (m *MockKeyedRepository[T, ID]) Delete(ctx context.Context, model T) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockKeyedRepositoryMockRecorder[T, ID]) Delete(ctx, model any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockKeyedRepository[T, ID])(nil).Delete), ctx, model)
+}
+
+// DeleteByID mocks base method.
+func (m *MockKeyedRepository[T, ID]) DeleteByID(ctx context.Context, id ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockKeyedRepositoryMockRecorder[T, ID]) DeleteByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockKeyedRepository[T, ID])(nil).DeleteByID), ctx, id)
+}
+
+// DeleteMany mocks base method.
+func (m *MockKeyedRepository[T, ID]) DeleteMany(ctx context.Context, models []T) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMany", ctx, models)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMany indicates an expected call of DeleteMany.
+func (mr *MockKeyedRepositoryMockRecorder[T, ID]) DeleteMany(ctx, models any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMany", reflect.TypeOf((*MockKeyedRepository[T, ID])(nil).DeleteMany), ctx, models)
+}
+
+// DeleteWhere mocks base method.
+func (m *MockKeyedRepository[T, ID]) DeleteWhere(ctx context.Context, spec Specification[T], opts ...BulkOption) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, spec}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteWhere", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWhere indicates an expected call of DeleteWhere.
+func (mr *MockKeyedRepositoryMockRecorder[T, ID]) DeleteWhere(ctx, spec any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, spec}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWhere", reflect.TypeOf((*MockKeyedRepository[T, ID])(nil).DeleteWhere), varargs...)
+}
+
+// Exists mocks base method.
+func (m *MockKeyedRepository[T, ID]) Exists(ctx context.Context, spec Specification[T]) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", ctx, spec)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockKeyedRepositoryMockRecorder[T, ID]) Exists(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockKeyedRepository[T, ID])(nil).Exists), ctx, spec)
+}
+
+// ExistsByID mocks base method.
+func (m *MockKeyedRepository[T, ID]) ExistsByID(ctx context.Context, id ID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistsByID", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistsByID indicates an expected call of ExistsByID.
+func (mr *MockKeyedRepositoryMockRecorder[T, ID]) ExistsByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsByID", reflect.TypeOf((*MockKeyedRepository[T, ID])(nil).ExistsByID), ctx, id)
+}
+
+// FindAll mocks base method.
+func (m *MockKeyedRepository[T, ID]) FindAll(ctx context.Context, spec Specification[T]) ([]T, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, spec)
+	ret0, _ := ret[0].([]T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockKeyedRepositoryMockRecorder[T, ID]) FindAll(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockKeyedRepository[T, ID])(nil).FindAll), ctx, spec)
+}
+
+// FindByID mocks base method.
+func (m *MockKeyedRepository[T, ID]) FindByID(ctx context.Context, id ID) (T, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockKeyedRepositoryMockRecorder[T, ID]) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockKeyedRepository[T, ID])(nil).FindByID), ctx, id)
+}
+
+// FindByIDs mocks base method.
+func (m *MockKeyedRepository[T, ID]) FindByIDs(ctx context.Context, ids []ID) ([]T, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", ctx, ids)
+	ret0, _ := ret[0].([]T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockKeyedRepositoryMockRecorder[T, ID]) FindByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockKeyedRepository[T, ID])(nil).FindByIDs), ctx, ids)
+}
+
+// FindCursor mocks base method.
+func (m *MockKeyedRepository[T, ID]) FindCursor(ctx context.Context, spec Specification[T], req CursorRequest) (CursorPage[T], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCursor", ctx, spec, req)
+	ret0, _ := ret[0].(CursorPage[T])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCursor indicates an expected call of FindCursor.
+func (mr *MockKeyedRepositoryMockRecorder[T, ID]) FindCursor(ctx, spec, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCursor", reflect.TypeOf((*MockKeyedRepository[T, ID])(nil).FindCursor), ctx, spec, req)
+}
+
+// FindFirst mocks base method.
+func (m *MockKeyedRepository[T, ID]) FindFirst(ctx context.Context, spec Specification[T]) (T, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindFirst", ctx, spec)
+	ret0, _ := ret[0].(T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindFirst indicates an expected call of FindFirst.
+func (mr *MockKeyedRepositoryMockRecorder[T, ID]) FindFirst(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFirst", reflect.TypeOf((*MockKeyedRepository[T, ID])(nil).FindFirst), ctx, spec)
+}
+
+// FindInBatches mocks base method.
+func (m *MockKeyedRepository[T, ID]) FindInBatches(ctx context.Context, spec Specification[T], batchSize int, fn func([]T) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindInBatches", ctx, spec, batchSize, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindInBatches indicates an expected call of FindInBatches.
+func (mr *MockKeyedRepositoryMockRecorder[T, ID]) FindInBatches(ctx, spec, batchSize, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindInBatches", reflect.TypeOf((*MockKeyedRepository[T, ID])(nil).FindInBatches), ctx, spec, batchSize, fn)
+}
+
+// FindOne mocks base method.
+func (m *MockKeyedRepository[T, ID]) FindOne(ctx context.Context, spec Specification[T]) (T, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOne", ctx, spec)
+	ret0, _ := ret[0].(T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOne indicates an expected call of FindOne.
+func (mr *MockKeyedRepositoryMockRecorder[T, ID]) FindOne(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOne", reflect.TypeOf((*MockKeyedRepository[T, ID])(nil).FindOne), ctx, spec)
+}
+
+// FindPage mocks base method.
+func (m *MockKeyedRepository[T, ID]) FindPage(ctx context.Context, spec Specification[T], req PageRequest) (Page[T], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPage", ctx, spec, req)
+	ret0, _ := ret[0].(Page[T])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPage indicates an expected call of FindPage.
+func (mr *MockKeyedRepositoryMockRecorder[T, ID]) FindPage(ctx, spec, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPage", reflect.TypeOf((*MockKeyedRepository[T, ID])(nil).FindPage), ctx, spec, req)
+}
+
+// GetGormInstance mocks base method.
+func (m *MockKeyedRepository[T, ID]) GetGormInstance(ctx context.Context) (*gorm.DB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGormInstance", ctx)
+	ret0, _ := ret[0].(*gorm.DB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGormInstance indicates an expected call of GetGormInstance.
+func (mr *MockKeyedRepositoryMockRecorder[T, ID]) GetGormInstance(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGormInstance", reflect.TypeOf((*MockKeyedRepository[T, ID])(nil).GetGormInstance), ctx)
+}
+
+// Insert mocks base method.
+func (m *MockKeyedRepository[T, ID]) Insert(ctx context.Context, model T) (T, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, model)
+	ret0, _ := ret[0].(T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockKeyedRepositoryMockRecorder[T, ID]) Insert(ctx, model any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockKeyedRepository[T, ID])(nil).Insert), ctx, model)
+}
+
+// InsertMany mocks base method.
+func (m *MockKeyedRepository[T, ID]) InsertMany(ctx context.Context, models []T) ([]T, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertMany", ctx, models)
+	ret0, _ := ret[0].([]T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertMany indicates an expected call of InsertMany.
+func (mr *MockKeyedRepositoryMockRecorder[T, ID]) InsertMany(ctx, models any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertMany", reflect.TypeOf((*MockKeyedRepository[T, ID])(nil).InsertMany), ctx, models)
+}
+
+// Iterate mocks base method.
+func (m *MockKeyedRepository[T, ID]) Iterate(ctx context.Context, spec Specification[T]) iter.Seq2[T, error] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Iterate", ctx, spec)
+	ret0, _ := ret[0].(iter.Seq2[T, error])
+	return ret0
+}
+
+// Iterate indicates an expected call of Iterate.
+func (mr *MockKeyedRepositoryMockRecorder[T, ID]) Iterate(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Iterate", reflect.TypeOf((*MockKeyedRepository[T, ID])(nil).Iterate), ctx, spec)
+}
+
+// QueryBySpec mocks base method.
+func (m *MockKeyedRepository[T, ID]) QueryBySpec(ctx context.Context, spec Specification[T]) (*gorm.DB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryBySpec", ctx, spec)
+	ret0, _ := ret[0].(*gorm.DB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryBySpec indicates an expected call of QueryBySpec.
+func (mr *MockKeyedRepositoryMockRecorder[T, ID]) QueryBySpec(ctx, spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBySpec", reflect.TypeOf((*MockKeyedRepository[T, ID])(nil).QueryBySpec), ctx, spec)
+}
+
+// Restore mocks base method.
+func (m *MockKeyedRepository[T, ID]) Restore(ctx context.Context, model T) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockKeyedRepositoryMockRecorder[T, ID]) Restore(ctx, model any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockKeyedRepository[T, ID])(nil).Restore), ctx, model)
+}
+
+// RestoreMany mocks base method.
+func (m *MockKeyedRepository[T, ID]) RestoreMany(ctx context.Context, models []T) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreMany", ctx, models)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreMany indicates an expected call of RestoreMany.
+func (mr *MockKeyedRepositoryMockRecorder[T, ID]) RestoreMany(ctx, models any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreMany", reflect.TypeOf((*MockKeyedRepository[T, ID])(nil).RestoreMany), ctx, models)
+}
+
+// SaveMany mocks base method.
+func (m *MockKeyedRepository[T, ID]) SaveMany(ctx context.Context, models []T) ([]T, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveMany", ctx, models)
+	ret0, _ := ret[0].([]T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveMany indicates an expected call of SaveMany.
+func (mr *MockKeyedRepositoryMockRecorder[T, ID]) SaveMany(ctx, models any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMany", reflect.TypeOf((*MockKeyedRepository[T, ID])(nil).SaveMany), ctx, models)
+}
+
+// SoftDelete mocks base method.
+func (m *MockKeyedRepository[T, ID]) SoftDelete(ctx context.Context, model T) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDelete", ctx, model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SoftDelete indicates an expected call of SoftDelete.
+func (mr *MockKeyedRepositoryMockRecorder[T, ID]) SoftDelete(ctx, model any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDelete", reflect.TypeOf((*MockKeyedRepository[T, ID])(nil).SoftDelete), ctx, model)
+}
+
+// SoftDeleteMany mocks base method.
+func (m *MockKeyedRepository[T, ID]) SoftDeleteMany(ctx context.Context, models []T) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDeleteMany", ctx, models)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SoftDeleteMany indicates an expected call of SoftDeleteMany.
+func (mr *MockKeyedRepositoryMockRecorder[T, ID]) SoftDeleteMany(ctx, models any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDeleteMany", reflect.TypeOf((*MockKeyedRepository[T, ID])(nil).SoftDeleteMany), ctx, models)
+}
+
+// Update mocks base method.
+func (m *MockKeyedRepository[T, ID]) Update(ctx context.Context, model T) (T, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, model)
+	ret0, _ := ret[0].(T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockKeyedRepositoryMockRecorder[T, ID]) Update(ctx, model any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockKeyedRepository[T, ID])(nil).Update), ctx, model)
+}
+
+// UpdateFields mocks base method.
+func (m *MockKeyedRepository[T, ID]) UpdateFields(ctx context.Context, model T, fields ...string) (T, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, model}
+	for _, a := range fields {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateFields", varargs...)
+	ret0, _ := ret[0].(T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFields indicates an expected call of UpdateFields.
+func (mr *MockKeyedRepositoryMockRecorder[T, ID]) UpdateFields(ctx, model any, fields ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, model}, fields...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFields", reflect.TypeOf((*MockKeyedRepository[T, ID])(nil).UpdateFields), varargs...)
+}
+
+// UpdateMap mocks base method.
+func (m *MockKeyedRepository[T, ID]) UpdateMap(ctx context.Context, id any, changes map[string]any) (T, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMap", ctx, id, changes)
+	ret0, _ := ret[0].(T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMap indicates an expected call of UpdateMap.
+func (mr *MockKeyedRepositoryMockRecorder[T, ID]) UpdateMap(ctx, id, changes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMap", reflect.TypeOf((*MockKeyedRepository[T, ID])(nil).UpdateMap), ctx, id, changes)
+}
+
+// UpdateWhere mocks base method.
+func (m *MockKeyedRepository[T, ID]) UpdateWhere(ctx context.Context, spec Specification[T], changes map[string]any, opts ...BulkOption) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, spec, changes}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateWhere", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWhere indicates an expected call of UpdateWhere.
+func (mr *MockKeyedRepositoryMockRecorder[T, ID]) UpdateWhere(ctx, spec, changes any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, spec, changes}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWhere", reflect.TypeOf((*MockKeyedRepository[T, ID])(nil).UpdateWhere), varargs...)
+}
+
+// Upsert mocks base method.
+func (m *MockKeyedRepository[T, ID]) Upsert(ctx context.Context, model T, opts UpsertOptions) (T, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, model, opts)
+	ret0, _ := ret[0].(T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockKeyedRepositoryMockRecorder[T, ID]) Upsert(ctx, model, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockKeyedRepository[T, ID])(nil).Upsert), ctx, model, opts)
+}
+
+// UpsertMany mocks base method.
+func (m *MockKeyedRepository[T, ID]) UpsertMany(ctx context.Context, models []T, opts UpsertOptions) ([]T, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertMany", ctx, models, opts)
+	ret0, _ := ret[0].([]T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertMany indicates an expected call of UpsertMany.
+func (mr *MockKeyedRepositoryMockRecorder[T, ID]) UpsertMany(ctx, models, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertMany", reflect.TypeOf((*MockKeyedRepository[T, ID])(nil).UpsertMany), ctx, models, opts)
+}
@@ -30,37 +30,83 @@ func Paginate(page, limit int) func(db *gorm.DB) *gorm.DB {
 // It uses internal.IsValidFieldName to validate the field name and prevent SQL injection.
 // Set ascending to true for ascending order, false for descending.
 func OrderBy(field string, ascending bool) func(db *gorm.DB) *gorm.DB {
+	return SortBy(Sort{Field: field, Desc: !ascending})
+}
+
+// SortBy returns a GORM scope that orders query results by each sort in turn.
+// Field names are validated like OrderBy. NULLS FIRST/LAST is emulated on MySQL, which lacks it.
+func SortBy(sorts ...Sort) func(db *gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
-		// Basic validation to prevent SQL injection
-		// Only allow alphanumeric characters, underscores, and dots for table.column
-		if !internal.IsValidFieldName(field) {
-			_ = db.AddError(eris.Errorf("invalid field name: %s", field))
-			return db
-		}
+		for _, sort := range sorts {
+			// Basic validation to prevent SQL injection
+			// Only allow alphanumeric characters, underscores, and dots for table.column
+			if !internal.IsValidFieldName(sort.Field) {
+				_ = db.AddError(eris.Errorf("invalid field name: %s", sort.Field))
+				return db
+			}
 
-		if ascending {
-			return db.Order(field + " ASC")
+			db = db.Order(sort.orderSQL(db.Dialector.Name()))
 		}
 
-		return db.Order(field + " DESC")
+		return db
 	}
 }
 
 // WhereBySpec returns a GORM scope that applies a WHERE clause based on the provided struct spec.
 // Non-zero fields in spec will be used as AND conditions in the query.
-func WhereBySpec[T any](spec T) func(db *gorm.DB) *gorm.DB {
+// Fields listed in fields are matched even when they hold a zero value (e.g. false, 0 or "");
+// they accept column or struct field names and are validated against the model's schema.
+// Pointer fields are matched whenever they are non-nil.
+func WhereBySpec[T any](spec T, fields ...string) func(db *gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
 		v := reflect.ValueOf(spec)
 		if v.Kind() == reflect.Ptr {
 			if v.IsNil() {
 				return db // nothing to filter
 			}
-			return db.Where(spec)
+			if len(fields) == 0 {
+				return db.Where(spec)
+			}
+			return whereBySpecFields(db, spec, v.Elem(), fields)
 		}
-		return db.Where(&spec)
+		if len(fields) == 0 {
+			return db.Where(&spec)
+		}
+		return whereBySpecFields(db, &spec, v, fields)
 	}
 }
 
+// whereBySpecFields matches the non-zero fields of spec plus the explicitly requested ones.
+func whereBySpecFields(db *gorm.DB, spec any, value reflect.Value, fields []string) *gorm.DB {
+	sch, err := internal.ParseSchema(db, spec)
+	if err != nil {
+		_ = db.AddError(err)
+		return db
+	}
+
+	selected := make(map[string]bool, len(fields))
+	for _, name := range fields {
+		field, err := internal.LookupField(sch, name)
+		if err != nil {
+			_ = db.AddError(err)
+			return db
+		}
+		selected[field.DBName] = true
+	}
+
+	columns := make([]any, 0, len(sch.Fields))
+	for _, field := range sch.Fields {
+		if field.DBName == "" {
+			continue
+		}
+		if _, isZero := field.ValueOf(db.Statement.Context, value); !isZero || selected[field.DBName] {
+			columns = append(columns, field.DBName)
+		}
+	}
+
+	return db.Where(spec, columns...)
+}
+
 // PreloadRelations returns a GORM scope that preloads the specified relations.
 // It eager loads related data to avoid N+1 query problems.
 func PreloadRelations(relations []string) func(db *gorm.DB) *gorm.DB {
@@ -97,7 +143,7 @@ func DefaultOrder() func(*gorm.DB) *gorm.DB {
 
 // ForUpdate returns a GORM scope that conditionally adds FOR UPDATE locking to queries.
 // When enable is true, it adds SELECT ... FOR UPDATE to prevent concurrent modifications.
-// Used for pessimistic locking in transaction-critical operations.
+// Used for pessimistic locking in transaction-critical operations. See Locking for other locking modes.
 func ForUpdate(enable bool) func(*gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
 		if enable {
@@ -112,11 +158,20 @@ type DeletedFilter struct {
 }
 
 func (df *DeletedFilter) WhereDeleted() func(*gorm.DB) *gorm.DB {
+	return df.WhereDeletedColumn("deleted_at")
+}
+
+// WhereDeletedColumn is like WhereDeleted for models whose deletion timestamp is stored in column.
+func (df *DeletedFilter) WhereDeletedColumn(column string) func(*gorm.DB) *gorm.DB {
 	return func(d *gorm.DB) *gorm.DB {
 		if df.filterType == nil {
 			return d
 		}
-		return df.filterType.WhereDeleted()(d)
+		if !internal.IsValidFieldName(column) {
+			_ = d.AddError(eris.Errorf("invalid field name: %s", column))
+			return d
+		}
+		return df.filterType.WhereDeleted(column)(d)
 	}
 }
 
@@ -2,6 +2,7 @@ package crud
 
 import (
 	"context"
+	"time"
 
 	"github.com/itsLeonB/go-crud/internal"
 	"gorm.io/gorm"
@@ -12,18 +13,101 @@ import (
 type Transactor interface {
 	// Begin starts a new database transaction and returns a context containing the transaction.
 	Begin(ctx context.Context) (context.Context, error)
+	// BeginWith is like Begin with the isolation level, read-only mode and timeout of opts.
+	// With a timeout, the returned context has a deadline after which the transaction is rolled back.
+	// Its Propagation is ignored.
+	BeginWith(ctx context.Context, opts TxOptions) (context.Context, error)
 	// Commit commits the current transaction in the context.
 	Commit(ctx context.Context) error
 	// Rollback rolls back the current transaction in the context without returning an error.
 	Rollback(ctx context.Context)
 	// WithinTransaction executes a service function within a database transaction.
+	// When ctx already holds a transaction, the function runs within a savepoint of it, so its failure
+	// only undoes its own writes. Transactors created WithJoinedNestedTransactions run it directly in the outer transaction.
 	WithinTransaction(ctx context.Context, serviceFn func(ctx context.Context) error) error
+	// WithinTransactionOpts is like WithinTransaction, with opts.Propagation deciding how a transaction
+	// already held in ctx is treated. The zero TxOptions behaves like WithinTransaction.
+	WithinTransactionOpts(ctx context.Context, opts TxOptions, serviceFn func(ctx context.Context) error) error
+}
+
+// TxOptions configures a transaction started by BeginWith or WithinTransactionOpts.
+// Isolation, ReadOnly, Timeout and Retry only apply when a new transaction is begun, so a nested call
+// that fails is retried by the outermost one. SQLite ignores Isolation and ReadOnly.
+type TxOptions = internal.TxOptions
+
+// Propagation decides how WithinTransactionOpts treats a transaction already held in the context.
+type Propagation = internal.Propagation
+
+const (
+	// PropagationRequired joins the transaction in the context, within a savepoint unless joining is configured,
+	// or begins a new one. It is the default.
+	PropagationRequired = internal.PropagationRequired
+	// PropagationRequiresNew always begins an independent transaction that commits or rolls back on its own,
+	// even when the context holds one.
+	PropagationRequiresNew = internal.PropagationRequiresNew
+	// PropagationMandatory joins the transaction in the context and fails with ErrNoTransaction without one.
+	PropagationMandatory = internal.PropagationMandatory
+	// PropagationNever runs without a transaction and fails with ErrTransactionExists when the context holds one.
+	PropagationNever = internal.PropagationNever
+	// PropagationSupports joins the transaction in the context, or runs without one.
+	PropagationSupports = internal.PropagationSupports
+)
+
+// RetryPolicy decides whether and when WithinTransactionOpts runs a failed transaction again.
+// Each attempt calls the service function with the context of a new transaction.
+type RetryPolicy = internal.RetryPolicy
+
+// Backoff returns how long to wait before retry number attempt, starting at 1.
+type Backoff = internal.Backoff
+
+const (
+	// DefaultRetryAttempts is the number of attempts of a RetryPolicy without MaxAttempts.
+	DefaultRetryAttempts = internal.DefaultRetryAttempts
+	// DefaultRetryBaseDelay and DefaultRetryMaxDelay bound the backoff of a RetryPolicy without one.
+	DefaultRetryBaseDelay = internal.DefaultRetryBaseDelay
+	DefaultRetryMaxDelay  = internal.DefaultRetryMaxDelay
+)
+
+// ExponentialBackoff doubles the delay from base on every retry, capped at maxDelay,
+// and waits a random duration up to that delay so that competing retries spread out.
+func ExponentialBackoff(base, maxDelay time.Duration) Backoff {
+	return internal.ExponentialBackoff(base, maxDelay)
+}
+
+// IsRetryable reports whether err is a transient concurrency failure worth running the transaction
+// again for: ErrSerializationFailure, ErrDeadlock or ErrDatabaseBusy. It is the default RetryPolicy.Retryable.
+func IsRetryable(err error) bool {
+	return internal.IsRetryable(err)
+}
+
+// TransactorOption configures optional behavior of a Transactor created by NewTransactor.
+type TransactorOption func(*transactorConfig)
+
+type transactorConfig struct {
+	joinNested bool
+}
+
+// WithJoinedNestedTransactions makes nested WithinTransaction calls join the outer transaction
+// instead of creating a savepoint. A failing nested call then leaves its writes in place
+// unless the error is returned through the outer call.
+func WithJoinedNestedTransactions() TransactorOption {
+	return func(cfg *transactorConfig) {
+		cfg.joinNested = true
+	}
 }
 
 // NewTransactor creates a new Transactor implementation using GORM.
 // The returned Transactor can be used to manage database transactions with context propagation.
-func NewTransactor(db *gorm.DB) Transactor {
-	return &internal.GormTransactor{DB: db}
+// Optional behavior can be configured with TransactorOption values.
+func NewTransactor(db *gorm.DB, opts ...TransactorOption) Transactor {
+	var cfg transactorConfig
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+
+	return &internal.GormTransactor{DB: db, JoinNested: cfg.joinNested}
 }
 
 // GetTxFromContext retrieves the current GORM transaction from the context.
@@ -31,3 +115,18 @@ func NewTransactor(db *gorm.DB) Transactor {
 func GetTxFromContext(ctx context.Context) (*gorm.DB, error) {
 	return internal.GetTxFromContext(ctx)
 }
+
+// AfterCommit registers fn to run after the transaction in ctx commits, for side effects such as publishing
+// events that must not happen when the transaction rolls back. Callbacks run in registration order with the
+// context the transaction was begun from; those registered within a savepoint that is rolled back are dropped.
+// Without a transaction in ctx, fn runs immediately. It fails for transactions not begun by a Transactor.
+func AfterCommit(ctx context.Context, fn func(ctx context.Context)) error {
+	return internal.AfterCommit(ctx, fn)
+}
+
+// AfterRollback registers fn to run after the transaction in ctx rolls back, or fails to commit.
+// Callbacks run in registration order with the context the transaction was begun from; those registered
+// within a savepoint run when it is rolled back. Without a transaction in ctx, fn runs immediately, like AfterCommit.
+func AfterRollback(ctx context.Context, fn func(ctx context.Context)) error {
+	return internal.AfterRollback(ctx, fn)
+}
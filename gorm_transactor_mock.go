@@ -55,6 +55,21 @@ func (mr *MockTransactorMockRecorder) Begin(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Begin", reflect.TypeOf((*MockTransactor)(nil).Begin), ctx)
 }
 
+// BeginWith mocks base method.
+func (m *MockTransactor) BeginWith(ctx context.Context, opts TxOptions) (context.Context, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginWith", ctx, opts)
+	ret0, _ := ret[0].(context.Context)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginWith indicates an expected call of BeginWith.
+func (mr *MockTransactorMockRecorder) BeginWith(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginWith", reflect.TypeOf((*MockTransactor)(nil).BeginWith), ctx, opts)
+}
+
 // Commit mocks base method.
 func (m *MockTransactor) Commit(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -94,3 +109,17 @@ func (mr *MockTransactorMockRecorder) WithinTransaction(ctx, serviceFn any) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTransaction", reflect.TypeOf((*MockTransactor)(nil).WithinTransaction), ctx, serviceFn)
 }
+
+// WithinTransactionOpts mocks base method.
+func (m *MockTransactor) WithinTransactionOpts(ctx context.Context, opts TxOptions, serviceFn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTransactionOpts", ctx, opts, serviceFn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTransactionOpts indicates an expected call of WithinTransactionOpts.
+func (mr *MockTransactorMockRecorder) WithinTransactionOpts(ctx, opts, serviceFn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTransactionOpts", reflect.TypeOf((*MockTransactor)(nil).WithinTransactionOpts), ctx, opts, serviceFn)
+}
@@ -0,0 +1,69 @@
+package internal
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/rotisserie/eris"
+)
+
+var ErrInvalidCursor = eris.New("invalid cursor")
+
+// Cursor is the decoded form of a keyset pagination cursor.
+// Sort records the sort it was created for, so a cursor cannot be replayed against a different sort.
+type Cursor struct {
+	Sort   string            `json:"s"`
+	Values []json.RawMessage `json:"v"`
+}
+
+// EncodeCursor serializes the cursor as URL-safe base64, appending an HMAC-SHA256 signature when secret is set.
+func EncodeCursor(c Cursor, secret []byte) (string, error) {
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return "", eris.Wrap(err, "error encoding cursor")
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(payload)
+	if len(secret) == 0 {
+		return token, nil
+	}
+
+	return token + "." + base64.RawURLEncoding.EncodeToString(sign(payload, secret)), nil
+}
+
+// DecodeCursor parses a token produced by EncodeCursor, verifying its signature when secret is set.
+func DecodeCursor(token string, secret []byte) (Cursor, error) {
+	var c Cursor
+
+	encoded, signature, signed := strings.Cut(token, ".")
+	if signed != (len(secret) > 0) {
+		return c, eris.Wrap(ErrInvalidCursor, "unexpected cursor signature")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return c, eris.Wrap(ErrInvalidCursor, "malformed cursor")
+	}
+
+	if signed {
+		mac, err := base64.RawURLEncoding.DecodeString(signature)
+		if err != nil || !hmac.Equal(mac, sign(payload, secret)) {
+			return c, eris.Wrap(ErrInvalidCursor, "cursor signature mismatch")
+		}
+	}
+
+	if err = json.Unmarshal(payload, &c); err != nil {
+		return c, eris.Wrap(ErrInvalidCursor, "malformed cursor")
+	}
+
+	return c, nil
+}
+
+func sign(payload, secret []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,299 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUniqueViolation      = eris.New("unique constraint violation")
+	ErrForeignKeyViolation  = eris.New("foreign key constraint violation")
+	ErrNotNullViolation     = eris.New("not null constraint violation")
+	ErrCheckViolation       = eris.New("check constraint violation")
+	ErrSerializationFailure = eris.New("serialization failure")
+	ErrDeadlock             = eris.New("deadlock detected")
+	ErrDatabaseBusy         = eris.New("database is busy")
+)
+
+// DBError is a classified database error. Kind is one of the sentinel errors above,
+// and the remaining fields carry whatever details the driver reported.
+type DBError struct {
+	Kind       error
+	Code       string
+	Table      string
+	Column     string
+	Constraint string
+	Err        error
+}
+
+func (e *DBError) Error() string {
+	if e.Err == nil {
+		return e.Kind.Error()
+	}
+	return fmt.Sprintf("%s: %s", e.Kind.Error(), e.Err.Error())
+}
+
+func (e *DBError) Is(target error) bool {
+	return target == e.Kind
+}
+
+func (e *DBError) Unwrap() error {
+	return e.Err
+}
+
+// ClassifyError translates driver errors from SQLite, PostgreSQL and MySQL into a *DBError.
+// Errors that cannot be classified are returned unchanged.
+func ClassifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var dbErr *DBError
+	if errors.As(err, &dbErr) {
+		return err
+	}
+
+	if classified := classifyChain(err); classified != nil {
+		return classified
+	}
+
+	switch {
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return &DBError{Kind: ErrUniqueViolation, Err: err}
+	case errors.Is(err, gorm.ErrForeignKeyViolated):
+		return &DBError{Kind: ErrForeignKeyViolation, Err: err}
+	case errors.Is(err, gorm.ErrCheckConstraintViolated):
+		return &DBError{Kind: ErrCheckViolation, Err: err}
+	}
+
+	return err
+}
+
+func classifyChain(err error) *DBError {
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if dbErr := classifyPostgres(e); dbErr != nil {
+			return dbErr
+		}
+		if dbErr := classifyMySQL(e); dbErr != nil {
+			return dbErr
+		}
+		if dbErr := classifySQLite(e); dbErr != nil {
+			return dbErr
+		}
+	}
+	return nil
+}
+
+var postgresKinds = map[string]error{
+	"23505": ErrUniqueViolation,
+	"23503": ErrForeignKeyViolation,
+	"23502": ErrNotNullViolation,
+	"23514": ErrCheckViolation,
+	"40001": ErrSerializationFailure,
+	"40P01": ErrDeadlock,
+}
+
+// classifyPostgres handles pgx (*pgconn.PgError) and lib/pq (*pq.Error), both of which expose SQLState.
+func classifyPostgres(err error) *DBError {
+	pgErr, ok := err.(interface{ SQLState() string })
+	if !ok {
+		return nil
+	}
+
+	code := pgErr.SQLState()
+	kind, ok := postgresKinds[code]
+	if !ok {
+		return nil
+	}
+
+	return &DBError{
+		Kind:       kind,
+		Code:       code,
+		Table:      stringField(err, "TableName", "Table"),
+		Column:     stringField(err, "ColumnName", "Column"),
+		Constraint: stringField(err, "ConstraintName", "Constraint"),
+		Err:        err,
+	}
+}
+
+var (
+	mysqlDuplicatePattern  = regexp.MustCompile(`for key '([^']+)'`)
+	mysqlColumnPattern     = regexp.MustCompile("Column '([^']+)'")
+	mysqlFieldPattern      = regexp.MustCompile("Field '([^']+)'")
+	mysqlForeignKeyPattern = regexp.MustCompile("CONSTRAINT `([^`]+)` FOREIGN KEY \\(`([^`]+)`\\)")
+	mysqlCheckPattern      = regexp.MustCompile(`[Cc]heck constraint '([^']+)'`)
+)
+
+// classifyMySQL handles go-sql-driver (*mysql.MySQLError), identified by its numeric error code.
+func classifyMySQL(err error) *DBError {
+	number, ok := uintField(err, "Number")
+	if !ok {
+		return nil
+	}
+
+	msg := stringField(err, "Message")
+	if msg == "" {
+		msg = err.Error()
+	}
+
+	dbErr := &DBError{Code: fmt.Sprint(number), Err: err}
+	switch number {
+	case 1062, 1586:
+		dbErr.Kind = ErrUniqueViolation
+		dbErr.Constraint = firstMatch(mysqlDuplicatePattern, msg, 1)
+	case 1216, 1217, 1451, 1452:
+		dbErr.Kind = ErrForeignKeyViolation
+		dbErr.Constraint = firstMatch(mysqlForeignKeyPattern, msg, 1)
+		dbErr.Column = firstMatch(mysqlForeignKeyPattern, msg, 2)
+	case 1048:
+		dbErr.Kind = ErrNotNullViolation
+		dbErr.Column = firstMatch(mysqlColumnPattern, msg, 1)
+	case 1364:
+		dbErr.Kind = ErrNotNullViolation
+		dbErr.Column = firstMatch(mysqlFieldPattern, msg, 1)
+	case 3819:
+		dbErr.Kind = ErrCheckViolation
+		dbErr.Constraint = firstMatch(mysqlCheckPattern, msg, 1)
+	case 1213:
+		dbErr.Kind = ErrDeadlock
+	default:
+		return nil
+	}
+
+	return dbErr
+}
+
+var sqliteKinds = map[int64]error{
+	275:  ErrCheckViolation,      // SQLITE_CONSTRAINT_CHECK
+	787:  ErrForeignKeyViolation, // SQLITE_CONSTRAINT_FOREIGNKEY
+	1299: ErrNotNullViolation,    // SQLITE_CONSTRAINT_NOTNULL
+	1555: ErrUniqueViolation,     // SQLITE_CONSTRAINT_PRIMARYKEY
+	2067: ErrUniqueViolation,     // SQLITE_CONSTRAINT_UNIQUE
+}
+
+var sqliteMessageKinds = []struct {
+	prefix string
+	kind   error
+}{
+	{"UNIQUE constraint failed", ErrUniqueViolation},
+	{"FOREIGN KEY constraint failed", ErrForeignKeyViolation},
+	{"NOT NULL constraint failed", ErrNotNullViolation},
+	{"CHECK constraint failed", ErrCheckViolation},
+	{"database is locked", ErrDatabaseBusy},
+}
+
+const sqliteBusy = 5 // SQLITE_BUSY, the primary code of its extended codes
+
+// classifySQLite handles mattn/go-sqlite3 (sqlite3.Error) by its extended result code,
+// and falls back to SQLite's stable constraint messages for other drivers.
+func classifySQLite(err error) *DBError {
+	msg := err.Error()
+
+	var kind error
+	code, hasCode := intField(err, "ExtendedCode")
+	if hasCode {
+		kind = sqliteKinds[code]
+		if code&0xff == sqliteBusy {
+			kind = ErrDatabaseBusy
+		}
+	}
+	if kind == nil {
+		for _, mk := range sqliteMessageKinds {
+			if strings.Contains(msg, mk.prefix) {
+				kind = mk.kind
+				break
+			}
+		}
+	}
+	if kind == nil {
+		return nil
+	}
+
+	dbErr := &DBError{Kind: kind, Err: err}
+	if hasCode {
+		dbErr.Code = fmt.Sprint(code)
+	}
+
+	// Details follow the colon, e.g. "UNIQUE constraint failed: users.email".
+	_, detail, found := strings.Cut(msg, "constraint failed: ")
+	if !found {
+		return dbErr
+	}
+	detail = strings.TrimSpace(detail)
+	if kind == ErrCheckViolation {
+		dbErr.Constraint = detail
+		return dbErr
+	}
+
+	first, _, _ := strings.Cut(detail, ",")
+	if table, column, ok := strings.Cut(first, "."); ok {
+		dbErr.Table = table
+		dbErr.Column = column
+	} else {
+		dbErr.Column = first
+	}
+
+	return dbErr
+}
+
+func firstMatch(pattern *regexp.Regexp, s string, group int) string {
+	matches := pattern.FindStringSubmatch(s)
+	if len(matches) <= group {
+		return ""
+	}
+	return matches[group]
+}
+
+func structValue(v any) (reflect.Value, bool) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return reflect.Value{}, false
+		}
+		rv = rv.Elem()
+	}
+	return rv, rv.Kind() == reflect.Struct
+}
+
+func stringField(v any, names ...string) string {
+	rv, ok := structValue(v)
+	if !ok {
+		return ""
+	}
+	for _, name := range names {
+		if f := rv.FieldByName(name); f.IsValid() && f.Kind() == reflect.String && f.String() != "" {
+			return f.String()
+		}
+	}
+	return ""
+}
+
+func intField(v any, name string) (int64, bool) {
+	rv, ok := structValue(v)
+	if !ok {
+		return 0, false
+	}
+	f := rv.FieldByName(name)
+	if !f.IsValid() || !f.CanInt() {
+		return 0, false
+	}
+	return f.Int(), true
+}
+
+func uintField(v any, name string) (uint64, bool) {
+	rv, ok := structValue(v)
+	if !ok {
+		return 0, false
+	}
+	f := rv.FieldByName(name)
+	if !f.IsValid() || !f.CanUint() {
+		return 0, false
+	}
+	return f.Uint(), true
+}
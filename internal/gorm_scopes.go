@@ -3,20 +3,20 @@ package internal
 import "gorm.io/gorm"
 
 type DeletedFilterType interface {
-	WhereDeleted() func(*gorm.DB) *gorm.DB
+	WhereDeleted(column string) func(*gorm.DB) *gorm.DB
 }
 
 type ExcludeDeleted struct{}
 
-func (ed ExcludeDeleted) WhereDeleted() func(*gorm.DB) *gorm.DB {
+func (ed ExcludeDeleted) WhereDeleted(column string) func(*gorm.DB) *gorm.DB {
 	return func(d *gorm.DB) *gorm.DB {
-		return d.Where("deleted_at IS NULL")
+		return d.Where(column + " IS NULL")
 	}
 }
 
 type IncludeDeleted struct{}
 
-func (id IncludeDeleted) WhereDeleted() func(*gorm.DB) *gorm.DB {
+func (id IncludeDeleted) WhereDeleted(column string) func(*gorm.DB) *gorm.DB {
 	return func(d *gorm.DB) *gorm.DB {
 		return d
 	}
@@ -24,8 +24,8 @@ func (id IncludeDeleted) WhereDeleted() func(*gorm.DB) *gorm.DB {
 
 type OnlyDeleted struct{}
 
-func (od OnlyDeleted) WhereDeleted() func(*gorm.DB) *gorm.DB {
+func (od OnlyDeleted) WhereDeleted(column string) func(*gorm.DB) *gorm.DB {
 	return func(d *gorm.DB) *gorm.DB {
-		return d.Where("deleted_at IS NOT NULL")
+		return d.Where(column + " IS NOT NULL")
 	}
 }
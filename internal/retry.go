@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+const (
+	DefaultRetryAttempts  = 3
+	DefaultRetryBaseDelay = 10 * time.Millisecond
+	DefaultRetryMaxDelay  = time.Second
+)
+
+// Backoff returns how long to wait before retry number attempt, starting at 1.
+type Backoff func(attempt int) time.Duration
+
+// ExponentialBackoff doubles the delay from base on every retry, capped at maxDelay,
+// and waits a random duration up to that delay so that competing retries spread out.
+func ExponentialBackoff(base, maxDelay time.Duration) Backoff {
+	return func(attempt int) time.Duration {
+		// Compare before shifting, as base<<shift overflows for large bases
+		delay := maxDelay
+		if shift := max(attempt-1, 0); shift < 32 && base <= maxDelay>>shift {
+			delay = base << shift
+		}
+		if delay <= 0 {
+			return 0
+		}
+		return rand.N(delay + 1)
+	}
+}
+
+// RetryPolicy decides whether and when a failed transaction is run again.
+type RetryPolicy struct {
+	MaxAttempts int                  // Total attempts including the first; DefaultRetryAttempts when below 1
+	Backoff     Backoff              // Delay between attempts; jittered exponential backoff when nil
+	Retryable   func(err error) bool // Errors worth another attempt; IsRetryable when nil
+}
+
+// IsRetryable reports whether err is a transient concurrency failure: a serialization failure,
+// a deadlock or a busy database.
+func IsRetryable(err error) bool {
+	err = ClassifyError(err)
+	return errors.Is(err, ErrSerializationFailure) || errors.Is(err, ErrDeadlock) || errors.Is(err, ErrDatabaseBusy)
+}
+
+// run calls fn until it succeeds, fails with an error that is not retryable, runs out of attempts
+// or ctx is done, and returns the last error.
+func (p *RetryPolicy) run(ctx context.Context, fn func() error) error {
+	maxAttempts := p.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = DefaultRetryAttempts
+	}
+	backoff := p.Backoff
+	if backoff == nil {
+		backoff = ExponentialBackoff(DefaultRetryBaseDelay, DefaultRetryMaxDelay)
+	}
+	retryable := p.Retryable
+	if retryable == nil {
+		retryable = IsRetryable
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= maxAttempts || !retryable(err) {
+			return err
+		}
+
+		timer := time.NewTimer(backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
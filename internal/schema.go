@@ -0,0 +1,31 @@
+package internal
+
+import (
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// ParseSchema returns the GORM schema of model using db's naming strategy and schema cache.
+func ParseSchema(db *gorm.DB, model any) (*schema.Schema, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return nil, eris.Wrap(err, "error parsing model schema")
+	}
+
+	return stmt.Schema, nil
+}
+
+// LookupField resolves name, given either as a column name or a struct field name, to a schema field.
+func LookupField(sch *schema.Schema, name string) (*schema.Field, error) {
+	if !IsValidFieldName(name) {
+		return nil, eris.Errorf("invalid field name: %s", name)
+	}
+
+	field := sch.LookUpField(name)
+	if field == nil || field.DBName == "" {
+		return nil, eris.Errorf("unknown field %s for %s", name, sch.Name)
+	}
+
+	return field, nil
+}
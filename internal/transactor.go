@@ -2,7 +2,9 @@ package internal
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"sync/atomic"
 
 	"github.com/itsLeonB/go-crud/lib"
 	"github.com/rotisserie/eris"
@@ -11,14 +13,35 @@ import (
 
 type GormTransactor struct {
 	DB *gorm.DB
+	// JoinNested makes nested WithinTransaction calls run directly in the outer transaction instead of a savepoint.
+	JoinNested bool
 }
 
+var savepointSeq atomic.Uint64
+
 func (t *GormTransactor) Begin(ctx context.Context) (context.Context, error) {
-	tx := t.DB.WithContext(ctx).Begin()
+	return t.BeginWith(ctx, TxOptions{})
+}
+
+func (t *GormTransactor) BeginWith(ctx context.Context, opts TxOptions) (context.Context, error) {
+	state := &txState{parent: ctx, cancel: func() {}}
+	if opts.Timeout > 0 {
+		// database/sql rolls the transaction back once its context is done
+		ctx, state.cancel = context.WithTimeout(ctx, opts.Timeout)
+	}
+
+	var tx *gorm.DB
+	if sqlOpts := opts.sqlOptions(); sqlOpts != nil {
+		tx = t.DB.WithContext(ctx).Begin(sqlOpts)
+	} else {
+		tx = t.DB.WithContext(ctx).Begin()
+	}
 	if err := tx.Error; err != nil {
-		return nil, eris.Wrap(err, lib.MsgTransactionError)
+		state.cancel()
+		return nil, eris.Wrap(ClassifyError(err), lib.MsgTransactionError)
 	}
 
+	ctx = context.WithValue(ctx, lib.ContextKeyTxState, state)
 	return context.WithValue(ctx, lib.ContextKeyGormTx, tx), nil
 }
 
@@ -30,8 +53,14 @@ func (t *GormTransactor) Commit(ctx context.Context) error {
 	if tx != nil {
 		err = tx.WithContext(ctx).Commit().Error
 		if err != nil {
-			return eris.Wrap(err, lib.MsgTransactionError)
+			endTxState(ctx, false)
+			// A transaction rolled back by its timeout reports itself as done; report the timeout instead
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				err = ctxErr
+			}
+			return eris.Wrap(ClassifyError(err), lib.MsgTransactionError)
 		}
+		endTxState(ctx, true)
 	}
 
 	return nil
@@ -48,6 +77,9 @@ func (t *GormTransactor) Rollback(ctx context.Context) {
 		return
 	}
 
+	// Also covers transactions database/sql already rolled back, e.g. on timeout; a no-op after Commit
+	defer endTxState(ctx, false)
+
 	err = tx.WithContext(ctx).Rollback().Error
 	if err != nil {
 		if err.Error() == "sql: transaction has already been committed or rolled back" {
@@ -60,19 +92,61 @@ func (t *GormTransactor) Rollback(ctx context.Context) {
 }
 
 func (t *GormTransactor) WithinTransaction(ctx context.Context, serviceFn func(ctx context.Context) error) error {
+	return t.WithinTransactionOpts(ctx, TxOptions{}, serviceFn)
+}
+
+func (t *GormTransactor) WithinTransactionOpts(ctx context.Context, opts TxOptions, serviceFn func(ctx context.Context) error) error {
 	// Check if we're already within a transaction
 	existingTx, err := GetTxFromContext(ctx)
 	if err != nil {
 		return eris.Wrap(err, "error checking existing transaction")
 	}
 
-	// If we're already in a transaction, just execute the service function
-	if existingTx != nil {
+	switch opts.Propagation {
+	case PropagationRequired:
+		if existingTx == nil {
+			return t.withinNew(ctx, opts, serviceFn)
+		}
+	case PropagationRequiresNew:
+		return t.withinNew(ctx, opts, serviceFn)
+	case PropagationMandatory:
+		if existingTx == nil {
+			return eris.Wrap(ErrNoTransaction, "Mandatory propagation requires a transaction")
+		}
+	case PropagationNever:
+		if existingTx != nil {
+			return eris.Wrap(ErrTransactionExists, "Never propagation must run outside a transaction")
+		}
+		return serviceFn(ctx)
+	case PropagationSupports:
+		if existingTx == nil {
+			return serviceFn(ctx)
+		}
+	default:
+		return eris.Errorf("unknown transaction propagation: %s", opts.Propagation)
+	}
+
+	// We're already in a transaction: run within a savepoint of it, or join it when configured
+	if t.JoinNested {
 		return serviceFn(ctx)
 	}
+	return t.withinSavepoint(ctx, existingTx, serviceFn)
+}
+
+// withinNew runs serviceFn in a new transaction, independent of any transaction held in ctx.
+// With a retry policy, every attempt runs in a transaction of its own.
+func (t *GormTransactor) withinNew(ctx context.Context, opts TxOptions, serviceFn func(ctx context.Context) error) error {
+	if opts.Retry == nil {
+		return t.attempt(ctx, opts, serviceFn)
+	}
 
-	// Start a new transaction
-	ctx, err = t.Begin(ctx)
+	return opts.Retry.run(ctx, func() error {
+		return t.attempt(ctx, opts, serviceFn)
+	})
+}
+
+func (t *GormTransactor) attempt(ctx context.Context, opts TxOptions, serviceFn func(ctx context.Context) error) error {
+	ctx, err := t.BeginWith(ctx, opts)
 	if err != nil {
 		return eris.Wrap(err, "error starting transaction")
 	}
@@ -85,6 +159,32 @@ func (t *GormTransactor) WithinTransaction(ctx context.Context, serviceFn func(c
 	return t.Commit(ctx)
 }
 
+// withinSavepoint runs serviceFn in a savepoint of tx, rolling back to it when serviceFn fails
+// so that the outer transaction can carry on without the partial writes.
+func (t *GormTransactor) withinSavepoint(ctx context.Context, tx *gorm.DB, serviceFn func(ctx context.Context) error) error {
+	name := fmt.Sprintf("go_crud_sp_%d", savepointSeq.Add(1))
+
+	if err := tx.WithContext(ctx).SavePoint(name).Error; err != nil {
+		return eris.Wrap(ClassifyError(err), "error creating savepoint")
+	}
+	state := txStateFrom(ctx)
+	hooks := state.mark()
+
+	if err := serviceFn(ctx); err != nil {
+		if rbErr := tx.WithContext(ctx).RollbackTo(name).Error; rbErr != nil {
+			log.Println("rollback to savepoint error:", rbErr)
+		}
+		state.rollbackTo(hooks)
+		return err
+	}
+
+	if err := tx.WithContext(ctx).Exec("RELEASE SAVEPOINT " + name).Error; err != nil {
+		return eris.Wrap(ClassifyError(err), "error releasing savepoint")
+	}
+
+	return nil
+}
+
 func GetTxFromContext(ctx context.Context) (*gorm.DB, error) {
 	trx := ctx.Value(lib.ContextKeyGormTx)
 	if trx != nil {
@@ -0,0 +1,65 @@
+package internal
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/rotisserie/eris"
+)
+
+var (
+	ErrNoTransaction     = eris.New("no transaction in context")
+	ErrTransactionExists = eris.New("transaction already in context")
+)
+
+// Propagation decides how WithinTransactionOpts treats a transaction already held in the context.
+type Propagation int
+
+const (
+	// PropagationRequired joins the transaction in the context, within a savepoint unless joining is configured,
+	// or begins a new one.
+	PropagationRequired Propagation = iota
+	// PropagationRequiresNew always begins an independent transaction that commits or rolls back on its own.
+	PropagationRequiresNew
+	// PropagationMandatory joins the transaction in the context and fails with ErrNoTransaction without one.
+	PropagationMandatory
+	// PropagationNever runs without a transaction and fails with ErrTransactionExists when the context holds one.
+	PropagationNever
+	// PropagationSupports joins the transaction in the context, or runs without one.
+	PropagationSupports
+)
+
+func (p Propagation) String() string {
+	switch p {
+	case PropagationRequired:
+		return "Required"
+	case PropagationRequiresNew:
+		return "RequiresNew"
+	case PropagationMandatory:
+		return "Mandatory"
+	case PropagationNever:
+		return "Never"
+	case PropagationSupports:
+		return "Supports"
+	default:
+		return "Propagation(" + strconv.Itoa(int(p)) + ")"
+	}
+}
+
+// TxOptions configures a transaction started by BeginWith or WithinTransactionOpts.
+// Isolation, ReadOnly, Timeout and Retry only apply when a new transaction is begun.
+type TxOptions struct {
+	Propagation Propagation
+	Isolation   sql.IsolationLevel
+	ReadOnly    bool
+	Timeout     time.Duration // Rolls the transaction back once exceeded; no limit when zero
+	Retry       *RetryPolicy  // Runs a failed transaction again in a new one; no retries when nil
+}
+
+func (o TxOptions) sqlOptions() *sql.TxOptions {
+	if o.Isolation == sql.LevelDefault && !o.ReadOnly {
+		return nil
+	}
+	return &sql.TxOptions{Isolation: o.Isolation, ReadOnly: o.ReadOnly}
+}
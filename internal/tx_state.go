@@ -0,0 +1,133 @@
+package internal
+
+import (
+	"context"
+	"sync"
+
+	"github.com/itsLeonB/go-crud/lib"
+	"github.com/rotisserie/eris"
+)
+
+// txState holds what a transaction started by the transactor needs to run and release when it ends.
+type txState struct {
+	parent context.Context // The context the transaction was begun from, passed to its callbacks
+	cancel context.CancelFunc
+
+	mu            sync.Mutex
+	ended         bool
+	afterCommit   []func(ctx context.Context)
+	afterRollback []func(ctx context.Context)
+}
+
+// hookMark records how many callbacks were registered when a savepoint was created.
+type hookMark struct {
+	afterCommit   int
+	afterRollback int
+}
+
+func txStateFrom(ctx context.Context) *txState {
+	state, _ := ctx.Value(lib.ContextKeyTxState).(*txState)
+	return state
+}
+
+// AfterCommit registers fn to run once the transaction in ctx commits, or runs it immediately without a transaction.
+func AfterCommit(ctx context.Context, fn func(ctx context.Context)) error {
+	tx, err := GetTxFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if tx == nil {
+		fn(ctx)
+		return nil
+	}
+
+	state := txStateFrom(ctx)
+	if state == nil {
+		return eris.New("transaction in context was not begun by a transactor")
+	}
+	return state.register(&state.afterCommit, fn)
+}
+
+// AfterRollback registers fn to run once the transaction in ctx rolls back, or runs it immediately without a transaction.
+func AfterRollback(ctx context.Context, fn func(ctx context.Context)) error {
+	tx, err := GetTxFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if tx == nil {
+		fn(ctx)
+		return nil
+	}
+
+	state := txStateFrom(ctx)
+	if state == nil {
+		return eris.New("transaction in context was not begun by a transactor")
+	}
+	return state.register(&state.afterRollback, fn)
+}
+
+func (s *txState) register(hooks *[]func(ctx context.Context), fn func(ctx context.Context)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ended {
+		return eris.New("transaction in context has already ended")
+	}
+	*hooks = append(*hooks, fn)
+	return nil
+}
+
+func (s *txState) mark() hookMark {
+	if s == nil {
+		return hookMark{}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return hookMark{afterCommit: len(s.afterCommit), afterRollback: len(s.afterRollback)}
+}
+
+// rollbackTo drops the commit callbacks registered since mark and runs the rollback callbacks,
+// as the writes they were registered for have been undone.
+func (s *txState) rollbackTo(mark hookMark) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	s.afterCommit = s.afterCommit[:mark.afterCommit]
+	rolledBack := s.afterRollback[mark.afterRollback:]
+	s.afterRollback = s.afterRollback[:mark.afterRollback:mark.afterRollback]
+	s.mu.Unlock()
+
+	for _, fn := range rolledBack {
+		fn(s.parent)
+	}
+}
+
+// endTxState releases the transaction started in ctx, if any, and runs the callbacks for its outcome once.
+func endTxState(ctx context.Context, committed bool) {
+	state := txStateFrom(ctx)
+	if state == nil {
+		return
+	}
+	state.cancel()
+
+	state.mu.Lock()
+	if state.ended {
+		state.mu.Unlock()
+		return
+	}
+	state.ended = true
+	hooks := state.afterRollback
+	if committed {
+		hooks = state.afterCommit
+	}
+	state.afterCommit, state.afterRollback = nil, nil
+	state.mu.Unlock()
+
+	for _, fn := range hooks {
+		fn(state.parent)
+	}
+}
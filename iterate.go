@@ -0,0 +1,107 @@
+package crud
+
+import (
+	"context"
+	"iter"
+	"reflect"
+
+	"github.com/itsLeonB/go-crud/internal"
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm/clause"
+)
+
+func (gr *gormRepository[T]) Iterate(ctx context.Context, spec Specification[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		db, err := gr.GetGormInstance(ctx)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+
+		// Bind ctx even to a transaction from the context, so cancellation stops the stream.
+		db = db.WithContext(ctx)
+
+		rows, err := db.Model(new(T)).
+			Scopes(gr.queryScopes(spec)...).
+			Scopes(gr.rangeScope(spec)).
+			Rows()
+
+		if err != nil {
+			yield(zero, eris.Wrap(internal.ClassifyError(err), "error querying data"))
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var model T
+			if err = db.ScanRows(rows, &model); err != nil {
+				yield(zero, eris.Wrap(internal.ClassifyError(err), "error scanning data"))
+				return
+			}
+			if !yield(model, nil) {
+				return
+			}
+		}
+
+		if err = rows.Err(); err != nil {
+			yield(zero, eris.Wrap(internal.ClassifyError(err), "error querying data"))
+		}
+	}
+}
+
+func (gr *gormRepository[T]) FindInBatches(ctx context.Context, spec Specification[T], batchSize int, fn func(batch []T) error) error {
+	if batchSize < 1 {
+		return eris.New("batch size must be positive")
+	}
+
+	meta, err := gr.meta()
+	if err != nil {
+		return err
+	}
+
+	pk, err := meta.primaryKey()
+	if err != nil {
+		return err
+	}
+
+	db, err := gr.GetGormInstance(ctx)
+	if err != nil {
+		return err
+	}
+	db = db.WithContext(ctx)
+
+	var last any
+	for {
+		if err = ctx.Err(); err != nil {
+			return eris.Wrap(err, "error querying data")
+		}
+
+		query := db.Scopes(gr.filterScopes(spec)...).
+			Scopes(gr.loadScopes(spec)...).
+			Order(clause.OrderByColumn{Column: pkColumn(pk)}).
+			Limit(batchSize)
+
+		if last != nil {
+			query = query.Where(clause.Gt{Column: pkColumn(pk), Value: last})
+		}
+
+		var batch []T
+		if err = query.Find(&batch).Error; err != nil {
+			return eris.Wrap(internal.ClassifyError(err), "error querying data")
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+
+		if err = fn(batch); err != nil {
+			return err
+		}
+		if len(batch) < batchSize {
+			return nil
+		}
+
+		last, _ = pk.ValueOf(ctx, reflect.ValueOf(&batch[len(batch)-1]).Elem())
+	}
+}
@@ -0,0 +1,167 @@
+package crud
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+
+	"github.com/itsLeonB/go-crud/internal"
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+type gormKeyedRepository[T any, ID comparable] struct {
+	*gormRepository[T]
+}
+
+func (gkr *gormKeyedRepository[T, ID]) FindByID(ctx context.Context, id ID) (T, error) {
+	var model T
+
+	pk, err := gkr.primaryKey()
+	if err != nil {
+		return model, err
+	}
+
+	db, err := gkr.GetGormInstance(ctx)
+	if err != nil {
+		return model, err
+	}
+
+	err = db.Scopes(gkr.filterScopes(Specification[T]{})...).
+		Where(clause.Eq{Column: pkColumn(pk), Value: id}).
+		Take(&model).
+		Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model, eris.Wrap(ErrNotFound, "error querying data")
+		}
+		return model, eris.Wrap(internal.ClassifyError(err), "error querying data")
+	}
+
+	return model, nil
+}
+
+func (gkr *gormKeyedRepository[T, ID]) FindByIDs(ctx context.Context, ids []ID) ([]T, error) {
+	if len(ids) < 1 {
+		return []T{}, nil
+	}
+
+	pk, err := gkr.primaryKey()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := gkr.GetGormInstance(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[ID]T, len(ids))
+	for chunk := range slices.Chunk(ids, gkr.cfg.idChunkSize) {
+		values := make([]any, len(chunk))
+		for i, id := range chunk {
+			values[i] = id
+		}
+
+		var models []T
+		err = db.Scopes(gkr.filterScopes(Specification[T]{})...).
+			Where(clause.IN{Column: pkColumn(pk), Values: values}).
+			Find(&models).
+			Error
+
+		if err != nil {
+			return nil, eris.Wrap(internal.ClassifyError(err), "error querying data")
+		}
+
+		for i := range models {
+			value, _ := pk.ValueOf(ctx, reflect.ValueOf(&models[i]).Elem())
+			byID[value.(ID)] = models[i]
+		}
+	}
+
+	results := make([]T, 0, len(byID))
+	for _, id := range ids {
+		if model, ok := byID[id]; ok {
+			results = append(results, model)
+		}
+	}
+
+	return results, nil
+}
+
+func (gkr *gormKeyedRepository[T, ID]) ExistsByID(ctx context.Context, id ID) (bool, error) {
+	pk, err := gkr.primaryKey()
+	if err != nil {
+		return false, err
+	}
+
+	db, err := gkr.GetGormInstance(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var count int64
+	err = db.Model(new(T)).
+		Scopes(gkr.filterScopes(Specification[T]{})...).
+		Where(clause.Eq{Column: pkColumn(pk), Value: id}).
+		Count(&count).
+		Error
+
+	if err != nil {
+		return false, eris.Wrap(internal.ClassifyError(err), "error querying data")
+	}
+
+	return count > 0, nil
+}
+
+func (gkr *gormKeyedRepository[T, ID]) DeleteByID(ctx context.Context, id ID) error {
+	pk, err := gkr.primaryKey()
+	if err != nil {
+		return err
+	}
+
+	db, err := gkr.GetGormInstance(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := db.Unscoped().
+		Where(clause.Eq{Column: pkColumn(pk), Value: id}).
+		Delete(new(T))
+
+	if result.Error != nil {
+		return eris.Wrap(internal.ClassifyError(result.Error), "error deleting data")
+	}
+	if result.RowsAffected == 0 {
+		return eris.Wrap(ErrNotFound, "error deleting data")
+	}
+
+	return nil
+}
+
+// primaryKey returns T's single primary key field, checking that its type matches ID.
+func (gkr *gormKeyedRepository[T, ID]) primaryKey() (*schema.Field, error) {
+	meta, err := gkr.meta()
+	if err != nil {
+		return nil, err
+	}
+
+	pk, err := meta.primaryKey()
+	if err != nil {
+		return nil, err
+	}
+
+	if idType := reflect.TypeFor[ID](); pk.FieldType != idType {
+		return nil, eris.Errorf("primary key %s of %s is %s, not %s", pk.Name, meta.schema.Name, pk.FieldType, idType)
+	}
+
+	return pk, nil
+}
+
+func pkColumn(pk *schema.Field) clause.Column {
+	return clause.Column{Table: clause.CurrentTable, Name: pk.DBName}
+}
@@ -3,7 +3,8 @@ package lib
 type txKey string
 
 const (
-	ContextKeyGormTx txKey = "go-crud.gormTx"
+	ContextKeyGormTx  txKey = "go-crud.gormTx"
+	ContextKeyTxState txKey = "go-crud.txState"
 
 	MsgTransactionError = "error processing transaction"
 )
@@ -0,0 +1,71 @@
+package crud
+
+import (
+	"github.com/itsLeonB/go-crud/internal"
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LockStrength is the row lock a locking read acquires.
+type LockStrength string
+
+const (
+	LockForUpdate      LockStrength = clause.LockingStrengthUpdate // FOR UPDATE
+	LockForNoKeyUpdate LockStrength = "NO KEY UPDATE"              // FOR NO KEY UPDATE (PostgreSQL only)
+	LockForShare       LockStrength = clause.LockingStrengthShare  // FOR SHARE
+	LockForKeyShare    LockStrength = "KEY SHARE"                  // FOR KEY SHARE (PostgreSQL only)
+)
+
+// LockWait is what a locking read does when a row is already locked by another transaction.
+type LockWait string
+
+const (
+	LockWaitDefault LockWait = ""                              // Block until the lock is released
+	LockNoWait      LockWait = clause.LockingOptionsNoWait     // Fail immediately
+	LockSkipLocked  LockWait = clause.LockingOptionsSkipLocked // Skip locked rows
+)
+
+// Lock describes a locking read (SELECT ... FOR <Strength> [OF <Of>] [<Wait>]).
+// Locks are only held until the surrounding transaction ends, so use them within WithinTransaction.
+// SQLite has no row-level locks, and its driver silently drops the locking clause.
+type Lock struct {
+	Strength LockStrength
+	Wait     LockWait
+	Of       string // Table to lock when the query joins several; defaults to all of them
+}
+
+// Locking returns a GORM scope that adds the locking clause described by lock.
+// A zero Lock adds nothing. Unknown strengths or wait policies and invalid table names are added to the query as errors.
+func Locking(lock Lock) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if lock == (Lock{}) {
+			return db
+		}
+
+		switch lock.Strength {
+		case LockForUpdate, LockForNoKeyUpdate, LockForShare, LockForKeyShare:
+		default:
+			_ = db.AddError(eris.Errorf("invalid lock strength: %q", lock.Strength))
+			return db
+		}
+
+		switch lock.Wait {
+		case LockWaitDefault, LockNoWait, LockSkipLocked:
+		default:
+			_ = db.AddError(eris.Errorf("invalid lock wait policy: %q", lock.Wait))
+			return db
+		}
+
+		locking := clause.Locking{Strength: string(lock.Strength), Options: string(lock.Wait)}
+		if lock.Of != "" {
+			if !internal.IsValidFieldName(lock.Of) {
+				_ = db.AddError(eris.Errorf("invalid table name: %s", lock.Of))
+				return db
+			}
+			locking.Table = clause.Table{Name: lock.Of}
+		}
+
+		return db.Clauses(locking)
+	}
+}
@@ -0,0 +1,171 @@
+package crud
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+
+	"github.com/itsLeonB/go-crud/internal"
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// modelMeta holds what a repository derives from the GORM schema of its model,
+// combined with the columns configured through RepositoryOption.
+type modelMeta struct {
+	schema        *schema.Schema
+	defaultOrder  []Sort
+	deletedColumn string
+	gormDeletedAt bool // deletedColumn is a gorm.DeletedAt, which GORM filters on its own unless Unscoped
+	versionColumn string
+}
+
+var (
+	deletedAtType   = reflect.TypeOf(gorm.DeletedAt{})
+	sqlNullTimeType = reflect.TypeOf(sql.NullTime{})
+
+	versionedEntityType = reflect.TypeOf(VersionedEntity{})
+)
+
+// meta resolves the model metadata once and caches it for the lifetime of the repository.
+func (gr *gormRepository[T]) meta() (*modelMeta, error) {
+	gr.metaOnce.Do(func() {
+		gr.metaCache, gr.metaErr = gr.resolveMeta()
+	})
+	return gr.metaCache, gr.metaErr
+}
+
+func (gr *gormRepository[T]) resolveMeta() (*modelMeta, error) {
+	sch, err := internal.ParseSchema(gr.db, new(T))
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &modelMeta{schema: sch}
+
+	if gr.cfg.defaultOrder != nil {
+		meta.defaultOrder = make([]Sort, len(gr.cfg.defaultOrder))
+		for i, sort := range gr.cfg.defaultOrder {
+			field, err := internal.LookupField(sch, sort.Field)
+			if err != nil {
+				return nil, eris.Wrap(err, "invalid default order")
+			}
+			sort.Field = field.DBName
+			meta.defaultOrder[i] = sort
+		}
+	} else {
+		meta.defaultOrder = detectDefaultOrder(sch)
+	}
+
+	if gr.cfg.deletedColumn != "" {
+		field, err := internal.LookupField(sch, gr.cfg.deletedColumn)
+		if err != nil {
+			return nil, eris.Wrap(err, "invalid deleted column")
+		}
+		meta.deletedColumn = field.DBName
+	} else {
+		meta.deletedColumn = detectDeletedColumn(sch)
+	}
+	if meta.deletedColumn != "" {
+		meta.gormDeletedAt = sch.LookUpField(meta.deletedColumn).FieldType == deletedAtType
+	}
+
+	if gr.cfg.versionColumn != "" {
+		field, err := internal.LookupField(sch, gr.cfg.versionColumn)
+		if err != nil {
+			return nil, eris.Wrap(err, "invalid version column")
+		}
+		if !isIntegerKind(field.FieldType.Kind()) {
+			return nil, eris.Errorf("version column %s must be an integer", field.DBName)
+		}
+		meta.versionColumn = field.DBName
+	} else {
+		meta.versionColumn = detectVersionColumn(sch)
+	}
+
+	return meta, nil
+}
+
+// detectDefaultOrder orders by the auto-create timestamp, newest first,
+// falling back to the primary key when the model has no creation column.
+func detectDefaultOrder(sch *schema.Schema) []Sort {
+	for _, field := range sch.Fields {
+		if field.DBName != "" && field.AutoCreateTime != 0 {
+			return []Sort{{Field: field.DBName, Desc: true}}
+		}
+	}
+
+	if pk := sch.PrioritizedPrimaryField; pk != nil {
+		return []Sort{{Field: pk.DBName, Desc: true}}
+	}
+
+	return nil
+}
+
+// detectDeletedColumn finds a gorm.DeletedAt field, or a sql.NullTime field named DeletedAt as used by BaseEntity.
+func detectDeletedColumn(sch *schema.Schema) string {
+	for _, field := range sch.Fields {
+		if field.DBName != "" && field.FieldType == deletedAtType {
+			return field.DBName
+		}
+	}
+
+	if field := sch.LookUpField("DeletedAt"); field != nil && field.DBName != "" && field.FieldType == sqlNullTimeType {
+		return field.DBName
+	}
+
+	return ""
+}
+
+// detectVersionColumn finds the Version field of an embedded VersionedEntity. Other fields named Version
+// may mean something else, so they are only used for optimistic locking when set WithVersionColumn.
+func detectVersionColumn(sch *schema.Schema) string {
+	field := sch.LookUpField("Version")
+	if field == nil || field.DBName == "" || len(field.StructField.Index) < 2 {
+		return ""
+	}
+
+	index := field.StructField.Index
+	owner := sch.ModelType.FieldByIndex(index[:len(index)-1]).Type
+	if owner.Kind() == reflect.Ptr {
+		owner = owner.Elem()
+	}
+	if owner != versionedEntityType {
+		return ""
+	}
+	return field.DBName
+}
+
+func isIntegerKind(kind reflect.Kind) bool {
+	return (kind >= reflect.Int && kind <= reflect.Int64) || (kind >= reflect.Uint && kind <= reflect.Uint64)
+}
+
+// primaryKey returns the model's single primary key field.
+func (meta *modelMeta) primaryKey() (*schema.Field, error) {
+	pk := meta.schema.PrioritizedPrimaryField
+	if pk == nil || len(meta.schema.PrimaryFields) != 1 {
+		return nil, eris.Errorf("%s must have exactly one primary key", meta.schema.Name)
+	}
+	return pk, nil
+}
+
+// primaryKeys returns the primary key column and the primary key values of models.
+func (gr *gormRepository[T]) primaryKeys(ctx context.Context, meta *modelMeta, models []T) (clause.Column, []any, error) {
+	pk := meta.schema.PrioritizedPrimaryField
+	if pk == nil {
+		return clause.Column{}, nil, eris.Errorf("%s has no primary key", meta.schema.Name)
+	}
+
+	ids := make([]any, len(models))
+	for i := range models {
+		id, isZero := pk.ValueOf(ctx, reflect.ValueOf(&models[i]).Elem())
+		if isZero {
+			return clause.Column{}, nil, eris.Errorf("model at index %d has no primary key value", i)
+		}
+		ids[i] = id
+	}
+
+	return clause.Column{Table: clause.CurrentTable, Name: pk.DBName}, ids, nil
+}
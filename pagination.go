@@ -0,0 +1,38 @@
+package crud
+
+const (
+	// DefaultPageSize is the page size used by FindPage when PageRequest.Limit is not set.
+	DefaultPageSize = 20
+	// DefaultMaxPageSize is the largest page size FindPage returns unless configured WithMaxPageSize.
+	DefaultMaxPageSize = 100
+)
+
+// PageRequest describes which page of results to fetch.
+// Page is 1-indexed; values below 1 are treated as the first page.
+type PageRequest struct {
+	Page  int
+	Limit int
+}
+
+func (pr PageRequest) normalize(maxPageSize int) PageRequest {
+	if pr.Page < 1 {
+		pr.Page = 1
+	}
+	if pr.Limit < 1 {
+		pr.Limit = min(DefaultPageSize, maxPageSize)
+	}
+	if pr.Limit > maxPageSize {
+		pr.Limit = maxPageSize
+	}
+	return pr
+}
+
+// Page is a single page of results returned by FindPage.
+type Page[T any] struct {
+	Items      []T
+	Total      int64 // Total number of records matching the specification
+	Page       int   // Current page, 1-indexed
+	Limit      int   // Page size actually applied
+	TotalPages int
+	HasNext    bool
+}
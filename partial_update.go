@@ -0,0 +1,169 @@
+package crud
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"github.com/itsLeonB/go-crud/internal"
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+func (gr *gormRepository[T]) UpdateFields(ctx context.Context, model T, fields ...string) (T, error) {
+	var zero T
+
+	if len(fields) < 1 {
+		return zero, eris.New("updated fields cannot be empty")
+	}
+
+	meta, err := gr.meta()
+	if err != nil {
+		return zero, err
+	}
+
+	pk, err := meta.primaryKey()
+	if err != nil {
+		return zero, err
+	}
+
+	rv := reflect.ValueOf(&model).Elem()
+	id, isZero := pk.ValueOf(ctx, rv)
+	if isZero {
+		return zero, eris.New("model has no primary key value")
+	}
+
+	changes := make(map[string]any, len(fields))
+	for _, name := range fields {
+		field, err := updatableField(meta.schema, name)
+		if err != nil {
+			return zero, err
+		}
+		changes[field.DBName], _ = field.ValueOf(ctx, rv)
+	}
+
+	db, err := gr.GetGormInstance(ctx)
+	if err != nil {
+		return zero, err
+	}
+
+	// Model(&model) lets GORM write the bumped update timestamp back to the returned model.
+	query := db.Model(&model).
+		Scopes(gr.deletedScope(DeletedFilter{})).
+		Where(clause.Eq{Column: pkColumn(pk), Value: id})
+
+	if meta.versionColumn != "" {
+		guard, version, err := meta.bumpVersion(ctx, rv)
+		if err != nil {
+			return zero, err
+		}
+		changes[meta.versionColumn] = version
+		query = query.Where(guard)
+	}
+
+	result := query.Updates(changes)
+
+	if result.Error != nil {
+		return zero, eris.Wrap(internal.ClassifyError(result.Error), "error updating data")
+	}
+	if result.RowsAffected == 0 {
+		if meta.versionColumn != "" {
+			return zero, gr.staleOrNotFound(db, pk, id, "error updating data")
+		}
+		return zero, eris.Wrap(ErrNotFound, "error updating data")
+	}
+
+	if gr.cfg.refreshOnUpdate {
+		return gr.reload(db, pk, id)
+	}
+
+	return model, nil
+}
+
+func (gr *gormRepository[T]) UpdateMap(ctx context.Context, id any, changes map[string]any) (T, error) {
+	var zero T
+
+	if id == nil || reflect.ValueOf(id).IsZero() {
+		return zero, eris.New("id cannot be zero value")
+	}
+	if len(changes) < 1 {
+		return zero, eris.New("updated fields cannot be empty")
+	}
+
+	meta, err := gr.meta()
+	if err != nil {
+		return zero, err
+	}
+
+	pk, err := meta.primaryKey()
+	if err != nil {
+		return zero, err
+	}
+
+	columns := make(map[string]any, len(changes))
+	for name, value := range changes {
+		field, err := updatableField(meta.schema, name)
+		if err != nil {
+			return zero, err
+		}
+		columns[field.DBName] = value
+	}
+	if meta.versionColumn != "" {
+		columns[meta.versionColumn] = meta.versionIncrement()
+	}
+
+	db, err := gr.GetGormInstance(ctx)
+	if err != nil {
+		return zero, err
+	}
+
+	result := db.Model(new(T)).
+		Scopes(gr.deletedScope(DeletedFilter{})).
+		Where(clause.Eq{Column: pkColumn(pk), Value: id}).
+		Updates(columns)
+
+	if result.Error != nil {
+		return zero, eris.Wrap(internal.ClassifyError(result.Error), "error updating data")
+	}
+	if result.RowsAffected == 0 {
+		return zero, eris.Wrap(ErrNotFound, "error updating data")
+	}
+
+	return gr.reload(db, pk, id)
+}
+
+// updatableField resolves name against the schema, rejecting primary keys and fields without a column.
+func updatableField(sch *schema.Schema, name string) (*schema.Field, error) {
+	field, err := internal.LookupField(sch, name)
+	if err != nil {
+		return nil, err
+	}
+	if field.PrimaryKey {
+		return nil, eris.Errorf("primary key %s cannot be updated", field.Name)
+	}
+	if field.DBName == "" || !field.Updatable {
+		return nil, eris.Errorf("field %s is not updatable", field.Name)
+	}
+	return field, nil
+}
+
+// reload fetches the record with the given primary key, regardless of its deleted state.
+func (gr *gormRepository[T]) reload(db *gorm.DB, pk *schema.Field, id any) (T, error) {
+	var model T
+
+	err := db.Unscoped().
+		Where(clause.Eq{Column: pkColumn(pk), Value: id}).
+		Take(&model).
+		Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model, eris.Wrap(ErrNotFound, "error reloading data")
+		}
+		return model, eris.Wrap(internal.ClassifyError(err), "error reloading data")
+	}
+
+	return model, nil
+}
@@ -0,0 +1,192 @@
+package crud
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/itsLeonB/go-crud/internal"
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// Preload describes a relation to eager load, along with the related records to include.
+// Relation is the relation's struct field name on the parent model, and Preloads are loaded
+// from the related model in turn. Field names in Filter, OrderBy and Select refer to the related model.
+type Preload struct {
+	Relation string
+	Filter   Filter    // Conditions on the related records
+	OrderBy  []Sort    // Order of the related records
+	Limit    int       // Maximum number of related records per parent; has-one and has-many relations only
+	Select   []string  // Columns to load; the keys needed to link records are always included
+	Preloads []Preload // Relations of the related model to load
+}
+
+// rowNumberColumn ranks related records within each parent when a Preload has a Limit.
+const rowNumberColumn = "crud_preload_row"
+
+// PreloadWith returns a GORM scope that eager loads the preloads of model T.
+// Relation and field names are validated against the schema when the query is built,
+// and invalid ones are added to the query as errors instead of being sent to the database.
+func PreloadWith[T any](preloads ...Preload) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if len(preloads) == 0 {
+			return db
+		}
+
+		sch, err := internal.ParseSchema(db, new(T))
+		if err != nil {
+			_ = db.AddError(err)
+			return db
+		}
+
+		preloaded, err := applyPreloads(db, sch, "", preloads)
+		if err != nil {
+			_ = db.AddError(err)
+			return db
+		}
+
+		return preloaded
+	}
+}
+
+func applyPreloads(db *gorm.DB, sch *schema.Schema, prefix string, preloads []Preload) (*gorm.DB, error) {
+	for _, preload := range preloads {
+		rel, ok := sch.Relationships.Relations[preload.Relation]
+		if !ok {
+			return nil, eris.Errorf("%s has no relation %s", sch.Name, preload.Relation)
+		}
+
+		scope, err := preload.scope(db, rel)
+		if err != nil {
+			return nil, err
+		}
+
+		path := prefix + rel.Name
+		db = db.Preload(path, scope)
+
+		if db, err = applyPreloads(db, rel.FieldSchema, path+".", preload.Preloads); err != nil {
+			return nil, err
+		}
+	}
+
+	return db, nil
+}
+
+// scope resolves the preload against the related schema and returns the condition GORM applies to the preload query.
+func (p Preload) scope(db *gorm.DB, rel *schema.Relationship) (func(*gorm.DB) *gorm.DB, error) {
+	related := rel.FieldSchema
+
+	var filter clause.Expression
+	if p.Filter != nil {
+		expr, err := p.Filter.expression(&filterContext{db: db, schema: related})
+		if err != nil {
+			return nil, err
+		}
+		filter = expr
+	}
+
+	sorts := make([]Sort, len(p.OrderBy))
+	for i, sort := range p.OrderBy {
+		field, err := internal.LookupField(related, sort.Field)
+		if err != nil {
+			return nil, err
+		}
+		sort.Field = field.DBName
+		sorts[i] = sort
+	}
+
+	selected, err := lookupColumns(related, p.Select)
+	if err != nil {
+		return nil, err
+	}
+	if len(selected) > 0 {
+		selected = appendMissing(selected, linkColumns(rel)...)
+	}
+
+	if p.Limit > 0 && rel.Type != schema.HasOne && rel.Type != schema.HasMany {
+		return nil, eris.Errorf("preload limit is not supported on %s relation %s", rel.Type, rel.Name)
+	}
+
+	return func(tx *gorm.DB) *gorm.DB {
+		if p.Limit > 0 {
+			tx = limitPerParent(tx, rel, filter, sorts, p.Limit)
+		} else if filter != nil {
+			tx = tx.Where(filter)
+		}
+		if len(selected) > 0 {
+			tx = tx.Select(selected)
+		}
+		return SortBy(sorts...)(tx)
+	}, nil
+}
+
+// limitPerParent reads the related records from a subquery that ranks them within each parent with ROW_NUMBER,
+// keeping the first limit of each, so the limit applies per parent rather than to the whole preload.
+func limitPerParent(tx *gorm.DB, rel *schema.Relationship, filter clause.Expression, sorts []Sort, limit int) *gorm.DB {
+	related := rel.FieldSchema
+	dialect := tx.Dialector.Name()
+
+	partition := make([]string, 0, len(rel.References))
+	for _, ref := range rel.References {
+		if ref.ForeignKey != nil && ref.ForeignKey.Schema == related {
+			partition = append(partition, tx.Statement.Quote(ref.ForeignKey.DBName))
+		}
+	}
+
+	order := make([]string, 0, len(sorts)+len(related.PrimaryFieldDBNames))
+	for _, sort := range sorts {
+		sort.Field = tx.Statement.Quote(sort.Field)
+		order = append(order, sort.orderSQL(dialect))
+	}
+	for _, pk := range related.PrimaryFieldDBNames {
+		order = append(order, tx.Statement.Quote(pk))
+	}
+
+	inner := tx.Session(&gorm.Session{NewDB: true}).
+		Model(reflect.New(related.ModelType).Interface()).
+		Table(related.Table).
+		Select("*, ROW_NUMBER() OVER (PARTITION BY " + strings.Join(partition, ", ") +
+			" ORDER BY " + strings.Join(order, ", ") + ") AS " + rowNumberColumn)
+
+	// GORM has already restricted the preload to the parents' keys; rank only their records.
+	if where, ok := tx.Statement.Clauses["WHERE"].Expression.(clause.Where); ok && len(where.Exprs) > 0 {
+		inner = inner.Where(clause.And(where.Exprs...))
+		delete(tx.Statement.Clauses, "WHERE")
+	}
+	if filter != nil {
+		inner = inner.Where(filter)
+	}
+
+	// Alias the subquery by the bare table name, so columns qualified with the current table still resolve.
+	alias := related.Table[strings.LastIndexByte(related.Table, '.')+1:]
+	tx = tx.Table("(?) AS "+tx.Statement.Quote(alias), inner)
+	tx.Statement.Table = alias
+
+	return tx.Where(clause.Lte{Column: clause.Column{Name: rowNumberColumn}, Value: limit})
+}
+
+// linkColumns returns the columns of the related model that GORM needs to attach related records to their parents.
+func linkColumns(rel *schema.Relationship) []string {
+	columns := make([]string, 0, len(rel.References))
+	for _, ref := range rel.References {
+		if ref.ForeignKey != nil && ref.ForeignKey.Schema == rel.FieldSchema {
+			columns = appendMissing(columns, ref.ForeignKey.DBName)
+		}
+		if ref.PrimaryKey != nil && ref.PrimaryKey.Schema == rel.FieldSchema {
+			columns = appendMissing(columns, ref.PrimaryKey.DBName)
+		}
+	}
+	return appendMissing(columns, rel.FieldSchema.PrimaryFieldDBNames...)
+}
+
+func appendMissing(columns []string, names ...string) []string {
+	for _, name := range names {
+		if !slices.Contains(columns, name) {
+			columns = append(columns, name)
+		}
+	}
+	return columns
+}
@@ -0,0 +1,97 @@
+package crud
+
+import (
+	"context"
+
+	"github.com/itsLeonB/go-crud/internal"
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+)
+
+func (gr *gormRepository[T]) QueryBySpec(ctx context.Context, spec Specification[T]) (*gorm.DB, error) {
+	db, err := gr.GetGormInstance(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.Model(new(T)).
+		Scopes(gr.queryScopes(spec)...).
+		Scopes(gr.rangeScope(spec)), nil
+}
+
+// selectScope restricts the loaded columns to spec.Select, minus spec.Omit, validated against the schema.
+func (gr *gormRepository[T]) selectScope(spec Specification[T]) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if len(spec.Select) == 0 && len(spec.Omit) == 0 {
+			return db
+		}
+
+		meta, err := gr.meta()
+		if err != nil {
+			_ = db.AddError(err)
+			return db
+		}
+
+		selected, err := lookupColumns(meta.schema, spec.Select)
+		if err != nil {
+			_ = db.AddError(err)
+			return db
+		}
+		omitted, err := lookupColumns(meta.schema, spec.Omit)
+		if err != nil {
+			_ = db.AddError(err)
+			return db
+		}
+
+		if len(selected) > 0 {
+			db = db.Select(selected)
+		}
+		if len(omitted) > 0 {
+			db = db.Omit(omitted...)
+		}
+
+		return db
+	}
+}
+
+// FindAllAs retrieves the records matching spec as DTO values, loading only the columns DTO has fields for
+// (or spec.Select, when set). DTO fields are matched to columns by GORM's naming strategy.
+func FindAllAs[T, DTO any](ctx context.Context, repo Repository[T], spec Specification[T]) ([]DTO, error) {
+	db, err := repo.QueryBySpec(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []DTO
+	if err = db.Find(&results).Error; err != nil {
+		return nil, eris.Wrap(internal.ClassifyError(err), "error querying data")
+	}
+
+	return results, nil
+}
+
+// Pluck retrieves a single column of the records matching spec.
+// The field accepts a column or struct field name and is validated against T's schema. Select and Omit are ignored.
+func Pluck[V, T any](ctx context.Context, repo Repository[T], spec Specification[T], field string) ([]V, error) {
+	spec.Select, spec.Omit = nil, nil
+	db, err := repo.QueryBySpec(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	sch, err := internal.ParseSchema(db, new(T))
+	if err != nil {
+		return nil, err
+	}
+	column, err := internal.LookupField(sch, field)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []V
+	if err = db.Pluck(column.DBName, &values).Error; err != nil {
+		return nil, eris.Wrap(internal.ClassifyError(err), "error querying data")
+	}
+
+	return values, nil
+}
@@ -0,0 +1,126 @@
+package crud
+
+import (
+	"fmt"
+
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+type relationFilter struct {
+	relation string
+	filter   Filter
+	negate   bool
+}
+
+func (f relationFilter) expression(fc *filterContext) (clause.Expression, error) {
+	rel, ok := fc.schema.Relationships.Relations[f.relation]
+	if !ok {
+		return nil, eris.Errorf("%s has no relation %s", fc.schema.Name, f.relation)
+	}
+
+	related := &filterContext{
+		db:     fc.db,
+		schema: rel.FieldSchema,
+		table:  fmt.Sprintf("crud_rel_%d", fc.depth+1),
+		depth:  fc.depth + 1,
+	}
+	from := clause.Table{Name: rel.FieldSchema.Table, Alias: related.table}
+
+	var conds []clause.Expression
+	var exists clause.Expression
+
+	if rel.Type == schema.Many2Many {
+		join := clause.Table{Name: rel.JoinTable.Table, Alias: related.table + "_join"}
+		var on []clause.Expression
+		for _, ref := range rel.References {
+			joinColumn := clause.Column{Table: join.Alias, Name: ref.ForeignKey.DBName}
+			switch {
+			case ref.OwnPrimaryKey:
+				conds = append(conds, clause.Eq{Column: joinColumn, Value: fc.columnOf(ref.PrimaryKey)})
+			case ref.PrimaryValue != "":
+				conds = append(conds, clause.Eq{Column: joinColumn, Value: ref.PrimaryValue})
+			default:
+				on = append(on, clause.Eq{Column: joinColumn, Value: related.columnOf(ref.PrimaryKey)})
+			}
+		}
+
+		conds, err := relatedConditions(related, conds, f.filter)
+		if err != nil {
+			return nil, err
+		}
+		exists = clause.Expr{
+			SQL:  "EXISTS (SELECT 1 FROM ? JOIN ? ON ? WHERE ?)",
+			Vars: []any{join, from, clause.And(on...), clause.And(conds...)},
+		}
+	} else {
+		for _, ref := range rel.References {
+			switch {
+			case ref.PrimaryValue != "":
+				conds = append(conds, clause.Eq{Column: related.columnOf(ref.ForeignKey), Value: ref.PrimaryValue})
+			case ref.OwnPrimaryKey:
+				conds = append(conds, clause.Eq{Column: related.columnOf(ref.ForeignKey), Value: fc.columnOf(ref.PrimaryKey)})
+			default:
+				conds = append(conds, clause.Eq{Column: related.columnOf(ref.PrimaryKey), Value: fc.columnOf(ref.ForeignKey)})
+			}
+		}
+
+		conds, err := relatedConditions(related, conds, f.filter)
+		if err != nil {
+			return nil, err
+		}
+		exists = clause.Expr{
+			SQL:  "EXISTS (SELECT 1 FROM ? WHERE ?)",
+			Vars: []any{from, clause.And(conds...)},
+		}
+	}
+
+	if f.negate {
+		return clause.Expr{SQL: "NOT ?", Vars: []any{exists}}, nil
+	}
+	return exists, nil
+}
+
+// relatedConditions adds the exclusion of soft-deleted related records and the inner filter to the join conditions.
+func relatedConditions(related *filterContext, conds []clause.Expression, filter Filter) ([]clause.Expression, error) {
+	if column := detectDeletedColumn(related.schema); column != "" {
+		conds = append(conds, clause.Eq{Column: clause.Column{Table: related.table, Name: column}, Value: nil})
+	}
+
+	if filter == nil {
+		return conds, nil
+	}
+
+	expr, err := filter.expression(related)
+	if err != nil {
+		return nil, err
+	}
+	if expr != nil {
+		conds = append(conds, expr)
+	}
+
+	return conds, nil
+}
+
+// Has matches rows with at least one related record in relation, which is the relation's struct field name.
+// Soft-deleted related records are ignored.
+func Has(relation string) Filter {
+	return relationFilter{relation, nil, false}
+}
+
+// DoesntHave matches rows without any related record in relation.
+func DoesntHave(relation string) Filter {
+	return relationFilter{relation, nil, true}
+}
+
+// WhereHas matches rows with at least one related record in relation that satisfies filter.
+// Field names in filter refer to the related model, and it may contain relation filters of its own.
+func WhereHas(relation string, filter Filter) Filter {
+	return relationFilter{relation, filter, false}
+}
+
+// WhereDoesntHave matches rows without any related record in relation that satisfies filter.
+func WhereDoesntHave(relation string, filter Filter) Filter {
+	return relationFilter{relation, filter, true}
+}
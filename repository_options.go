@@ -0,0 +1,109 @@
+package crud
+
+// DefaultIDChunkSize is the number of primary keys FindByIDs binds per query unless configured WithIDChunkSize.
+const DefaultIDChunkSize = 500
+
+// RepositoryOption configures optional behavior of a repository created by NewRepository.
+type RepositoryOption func(*repositoryConfig)
+
+type repositoryConfig struct {
+	strictFindFirst bool
+	maxPageSize     int
+	cursorSecret    []byte
+	defaultOrder    []Sort
+	deletedColumn   string
+	deletedFilter   *DeletedFilter
+	idChunkSize     int
+	refreshOnUpdate bool
+	versionColumn   string
+}
+
+func newRepositoryConfig(opts []RepositoryOption) repositoryConfig {
+	cfg := repositoryConfig{
+		maxPageSize: DefaultMaxPageSize,
+		idChunkSize: DefaultIDChunkSize,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	return cfg
+}
+
+// WithStrictFindFirst makes FindFirst return ErrNotFound instead of a zero value
+// when no record matches, so it behaves the same as FindOne.
+func WithStrictFindFirst() RepositoryOption {
+	return func(cfg *repositoryConfig) {
+		cfg.strictFindFirst = true
+	}
+}
+
+// WithMaxPageSize sets the largest page size FindPage will return.
+// Larger requested limits are reduced to this value. Values below 1 are ignored.
+func WithMaxPageSize(size int) RepositoryOption {
+	return func(cfg *repositoryConfig) {
+		if size > 0 {
+			cfg.maxPageSize = size
+		}
+	}
+}
+
+// WithCursorSecret signs the cursors produced by FindCursor with HMAC-SHA256 using secret,
+// so that tampered or unsigned cursors are rejected with ErrInvalidCursor.
+func WithCursorSecret(secret []byte) RepositoryOption {
+	return func(cfg *repositoryConfig) {
+		cfg.cursorSecret = secret
+	}
+}
+
+// WithDefaultOrder sets the order used when a specification has no OrderBy.
+// By default the repository orders by the model's creation timestamp (descending),
+// or by its primary key when it has none. Fields are validated against the model's schema.
+func WithDefaultOrder(sorts ...Sort) RepositoryOption {
+	return func(cfg *repositoryConfig) {
+		cfg.defaultOrder = sorts
+	}
+}
+
+// WithDeletedColumn sets the column DeletedFilter checks for soft-deleted records.
+// By default the repository uses the model's gorm.DeletedAt field, or a sql.NullTime field named DeletedAt.
+func WithDeletedColumn(column string) RepositoryOption {
+	return func(cfg *repositoryConfig) {
+		cfg.deletedColumn = column
+	}
+}
+
+// WithVersionColumn sets the integer column used for optimistic locking.
+// By default only models embedding VersionedEntity are versioned; other models need this option.
+func WithVersionColumn(column string) RepositoryOption {
+	return func(cfg *repositoryConfig) {
+		cfg.versionColumn = column
+	}
+}
+
+// WithDefaultDeletedFilter sets the DeletedFilter applied when a specification does not set one.
+// Repositories of models with a deleted column default to ExcludeDeleted.
+func WithDefaultDeletedFilter(filter DeletedFilter) RepositoryOption {
+	return func(cfg *repositoryConfig) {
+		cfg.deletedFilter = &filter
+	}
+}
+
+// WithIDChunkSize sets how many primary keys FindByIDs binds per query.
+// Lower it for drivers with small parameter limits. Values below 1 are ignored.
+func WithIDChunkSize(size int) RepositoryOption {
+	return func(cfg *repositoryConfig) {
+		if size > 0 {
+			cfg.idChunkSize = size
+		}
+	}
+}
+
+// WithRefreshOnUpdate makes UpdateFields reload the updated record, so the returned model
+// reflects columns changed by the database or by concurrent writers.
+func WithRefreshOnUpdate() RepositoryOption {
+	return func(cfg *repositoryConfig) {
+		cfg.refreshOnUpdate = true
+	}
+}
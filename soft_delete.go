@@ -0,0 +1,82 @@
+package crud
+
+import (
+	"context"
+
+	"github.com/itsLeonB/go-crud/internal"
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm/clause"
+)
+
+func (gr *gormRepository[T]) SoftDelete(ctx context.Context, model T) error {
+	if err := gr.checkZeroValue(model); err != nil {
+		return err
+	}
+
+	return gr.setDeleted(ctx, []T{model}, true)
+}
+
+func (gr *gormRepository[T]) SoftDeleteMany(ctx context.Context, models []T) error {
+	if len(models) < 1 {
+		return eris.Errorf("deleted models cannot be empty")
+	}
+
+	return gr.setDeleted(ctx, models, true)
+}
+
+func (gr *gormRepository[T]) Restore(ctx context.Context, model T) error {
+	if err := gr.checkZeroValue(model); err != nil {
+		return err
+	}
+
+	return gr.setDeleted(ctx, []T{model}, false)
+}
+
+func (gr *gormRepository[T]) RestoreMany(ctx context.Context, models []T) error {
+	if len(models) < 1 {
+		return eris.Errorf("restored models cannot be empty")
+	}
+
+	return gr.setDeleted(ctx, models, false)
+}
+
+// setDeleted sets or clears the deleted column of models, identified by their primary keys.
+func (gr *gormRepository[T]) setDeleted(ctx context.Context, models []T, deleted bool) error {
+	meta, err := gr.meta()
+	if err != nil {
+		return err
+	}
+	if meta.deletedColumn == "" {
+		return eris.Errorf("%s has no deleted column for soft delete", meta.schema.Name)
+	}
+
+	pk, ids, err := gr.primaryKeys(ctx, meta, models)
+	if err != nil {
+		return err
+	}
+
+	db, err := gr.GetGormInstance(ctx)
+	if err != nil {
+		return err
+	}
+
+	var value any
+	if deleted {
+		value = db.NowFunc()
+	}
+
+	err = db.Unscoped().
+		Model(new(T)).
+		Where(clause.IN{Column: pk, Values: ids}).
+		Update(meta.deletedColumn, value).
+		Error
+
+	if err != nil {
+		if deleted {
+			return eris.Wrap(internal.ClassifyError(err), "error soft deleting data")
+		}
+		return eris.Wrap(internal.ClassifyError(err), "error restoring data")
+	}
+
+	return nil
+}
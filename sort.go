@@ -0,0 +1,38 @@
+package crud
+
+// NullsOrder controls where NULL values are placed when sorting.
+type NullsOrder int
+
+const (
+	NullsDefault NullsOrder = iota // Database default placement
+	NullsFirst                     // NULLS FIRST
+	NullsLast                      // NULLS LAST
+)
+
+// Sort orders results by a single column.
+// Field accepts either a column name or a struct field name of the model.
+type Sort struct {
+	Field string
+	Desc  bool
+	Nulls NullsOrder
+}
+
+func (s Sort) orderSQL(dialect string) string {
+	direction := " ASC"
+	if s.Desc {
+		direction = " DESC"
+	}
+
+	switch {
+	case s.Nulls == NullsDefault:
+		return s.Field + direction
+	case dialect == "mysql" && s.Nulls == NullsFirst:
+		return s.Field + " IS NULL DESC, " + s.Field + direction
+	case dialect == "mysql":
+		return s.Field + " IS NULL ASC, " + s.Field + direction
+	case s.Nulls == NullsFirst:
+		return s.Field + direction + " NULLS FIRST"
+	default:
+		return s.Field + direction + " NULLS LAST"
+	}
+}
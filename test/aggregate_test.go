@@ -0,0 +1,89 @@
+package gocrud_test
+
+import (
+	"context"
+	"testing"
+
+	crud "github.com/itsLeonB/go-crud"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository_CountExists(t *testing.T) {
+	db := setupScopesTestDB(t)
+	seedFilterData(t, db)
+	repo := crud.NewRepository[TestModel](db)
+	ctx := context.Background()
+
+	count, err := repo.Count(ctx, crud.Specification[TestModel]{})
+	assert.NoError(t, err, "Count should not return error")
+	assert.Equal(t, int64(5), count, "Count should include all records")
+
+	count, err = repo.Count(ctx, crud.Specification[TestModel]{Filter: crud.Gte("age", 35), Limit: 1})
+	assert.NoError(t, err, "Count should not return error")
+	assert.Equal(t, int64(3), count, "Count should apply the filter and ignore Limit")
+
+	exists, err := repo.Exists(ctx, crud.Specification[TestModel]{Model: TestModel{Name: "Bob"}})
+	assert.NoError(t, err, "Exists should not return error")
+	assert.True(t, exists, "Exists should find matching records")
+
+	exists, err = repo.Exists(ctx, crud.Specification[TestModel]{Filter: crud.Gt("age", 100)})
+	assert.NoError(t, err, "Exists should not return error")
+	assert.False(t, exists, "Exists should report no match")
+}
+
+func TestRepository_CountExists_SoftDelete(t *testing.T) {
+	db := setupSoftDeleteTestDB(t)
+	repo := crud.NewRepository[NullTimeModel](db)
+	ctx := context.Background()
+
+	models, err := repo.InsertMany(ctx, []NullTimeModel{{Name: "Alice"}, {Name: "Bob"}})
+	assert.NoError(t, err, "Failed to insert test data")
+	err = repo.SoftDelete(ctx, models[0])
+	assert.NoError(t, err, "SoftDelete should not return error")
+
+	count, err := repo.Count(ctx, crud.Specification[NullTimeModel]{})
+	assert.NoError(t, err, "Count should not return error")
+	assert.Equal(t, int64(1), count, "Count should exclude soft-deleted records by default")
+
+	exists, err := repo.Exists(ctx, crud.Specification[NullTimeModel]{Model: NullTimeModel{Name: "Alice"}})
+	assert.NoError(t, err, "Exists should not return error")
+	assert.False(t, exists, "Exists should exclude soft-deleted records by default")
+
+	exists, err = repo.Exists(ctx, crud.Specification[NullTimeModel]{Model: NullTimeModel{Name: "Alice"}, DeletedFilter: crud.OnlyDeleted})
+	assert.NoError(t, err, "Exists should not return error")
+	assert.True(t, exists, "Exists should honor DeletedFilter")
+}
+
+func TestRepository_Aggregates(t *testing.T) {
+	db := setupScopesTestDB(t)
+	seedFilterData(t, db)
+	repo := crud.NewRepository[TestModel](db)
+	ctx := context.Background()
+	spec := crud.Specification[TestModel]{}
+
+	sum, err := crud.Sum[int](ctx, repo, spec, "age")
+	assert.NoError(t, err, "Sum should not return error")
+	assert.Equal(t, 175, sum, "Sum should add all ages")
+
+	avg, err := crud.Avg(ctx, repo, crud.Specification[TestModel]{Filter: crud.Lte("age", 30)}, "Age")
+	assert.NoError(t, err, "Avg should not return error")
+	assert.Equal(t, 27.5, avg, "Avg should apply the filter")
+
+	minAge, err := crud.Min[int](ctx, repo, spec, "age")
+	assert.NoError(t, err, "Min should not return error")
+	assert.Equal(t, 25, minAge, "Min should return the smallest age")
+
+	maxName, err := crud.Max[string](ctx, repo, spec, "name")
+	assert.NoError(t, err, "Max should not return error")
+	assert.Equal(t, "alfred", maxName, "Max should work on text columns")
+
+	empty, err := crud.Sum[int](ctx, repo, crud.Specification[TestModel]{Filter: crud.Gt("age", 100)}, "age")
+	assert.NoError(t, err, "Sum should not return error when nothing matches")
+	assert.Zero(t, empty, "Sum should return zero when nothing matches")
+
+	_, err = crud.Sum[int](ctx, repo, spec, "age); DROP TABLE test_models; --")
+	assert.Error(t, err, "Aggregates should reject invalid field names")
+
+	err = repo.Aggregate(ctx, spec, crud.AggregateFunc("COUNT(*); --"), "age", new(int))
+	assert.Error(t, err, "Aggregate should reject unsupported functions")
+}
@@ -0,0 +1,118 @@
+package gocrud_test
+
+import (
+	"context"
+	"testing"
+
+	crud "github.com/itsLeonB/go-crud"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository_UpdateWhere(t *testing.T) {
+	db := setupScopesTestDB(t)
+	seedFilterData(t, db)
+	repo := crud.NewRepository[TestModel](db)
+	ctx := context.Background()
+
+	affected, err := repo.UpdateWhere(ctx, crud.Specification[TestModel]{Filter: crud.Gte("age", 40)}, map[string]any{"name": "Senior"})
+	assert.NoError(t, err, "UpdateWhere should not return error")
+	assert.Equal(t, int64(2), affected, "UpdateWhere should report updated records")
+
+	count, err := repo.Count(ctx, crud.Specification[TestModel]{Model: TestModel{Name: "Senior"}})
+	assert.NoError(t, err, "Count should not return error")
+	assert.Equal(t, int64(2), count, "UpdateWhere should only update matching records")
+
+	_, err = repo.UpdateWhere(ctx, crud.Specification[TestModel]{}, map[string]any{"age": 0})
+	assert.Error(t, err, "UpdateWhere should refuse an empty specification")
+
+	_, err = repo.UpdateWhere(ctx, crud.Specification[TestModel]{Filter: crud.And()}, map[string]any{"age": 0})
+	assert.Error(t, err, "UpdateWhere should refuse a filter without conditions")
+
+	affected, err = repo.UpdateWhere(ctx, crud.Specification[TestModel]{}, map[string]any{"age": 1}, crud.AllowFullTable())
+	assert.NoError(t, err, "UpdateWhere should run on the full table when allowed")
+	assert.Equal(t, int64(5), affected, "UpdateWhere should update every record")
+
+	_, err = repo.UpdateWhere(ctx, crud.Specification[TestModel]{Filter: crud.Eq("age", 1)}, map[string]any{"id": 1})
+	assert.Error(t, err, "UpdateWhere should reject primary key changes")
+}
+
+func TestRepository_BulkGuard(t *testing.T) {
+	repo := crud.NewRepository[Order](setupPreloadTestDB(t))
+	ctx := context.Background()
+	before, err := repo.Count(ctx, crud.Specification[Order]{})
+	assert.NoError(t, err, "Count should not return error")
+
+	relationOnly := crud.Specification[Order]{Model: Order{Customer: &Customer{ID: 1}}}
+	_, err = repo.UpdateWhere(ctx, relationOnly, map[string]any{"status": "archived"})
+	assert.Error(t, err, "UpdateWhere should refuse a specification that only sets a relation")
+	_, err = repo.DeleteWhere(ctx, relationOnly)
+	assert.Error(t, err, "DeleteWhere should refuse a specification that only sets a relation")
+
+	_, err = repo.DeleteWhere(ctx, crud.Specification[Order]{Filter: crud.NotIn("status")})
+	assert.Error(t, err, "DeleteWhere should refuse a filter that matches everything")
+
+	after, err := repo.Count(ctx, crud.Specification[Order]{})
+	assert.NoError(t, err, "Count should not return error")
+	assert.Equal(t, before, after, "Refused bulk operations should leave every record in place")
+}
+
+func TestRepository_DeleteWhere(t *testing.T) {
+	db := setupSoftDeleteTestDB(t)
+	ctx := context.Background()
+
+	t.Run("soft delete", func(t *testing.T) {
+		repo := crud.NewRepository[NullTimeModel](db)
+		_, err := repo.InsertMany(ctx, []NullTimeModel{{Name: "Alice"}, {Name: "Bob"}, {Name: "Bob"}})
+		assert.NoError(t, err, "Failed to insert test data")
+
+		spec := crud.Specification[NullTimeModel]{Model: NullTimeModel{Name: "Bob"}}
+		affected, err := repo.DeleteWhere(ctx, spec)
+		assert.NoError(t, err, "DeleteWhere should not return error")
+		assert.Equal(t, int64(2), affected, "DeleteWhere should report deleted records")
+
+		affected, err = repo.DeleteWhere(ctx, spec)
+		assert.NoError(t, err, "DeleteWhere should not return error")
+		assert.Zero(t, affected, "DeleteWhere should skip records that are already soft deleted")
+
+		count, err := repo.Count(ctx, crud.Specification[NullTimeModel]{DeletedFilter: crud.OnlyDeleted})
+		assert.NoError(t, err, "Count should not return error")
+		assert.Equal(t, int64(2), count, "DeleteWhere should soft delete models with a deleted column")
+
+		spec.DeletedFilter = crud.OnlyDeleted
+		affected, err = repo.DeleteWhere(ctx, spec, crud.HardDelete())
+		assert.NoError(t, err, "DeleteWhere should not return error")
+		assert.Equal(t, int64(2), affected, "HardDelete should purge matching records")
+
+		count, err = repo.Count(ctx, crud.Specification[NullTimeModel]{DeletedFilter: crud.IncludeDeleted})
+		assert.NoError(t, err, "Count should not return error")
+		assert.Equal(t, int64(1), count, "HardDelete should remove the records")
+	})
+
+	t.Run("hard delete without deleted column", func(t *testing.T) {
+		repo := crud.NewRepository[TestModel](db)
+		_, err := repo.InsertMany(ctx, []TestModel{{Name: "Alice", Email: "alice@example.com"}, {Name: "Bob", Email: "bob@example.com"}})
+		assert.NoError(t, err, "Failed to insert test data")
+
+		_, err = repo.DeleteWhere(ctx, crud.Specification[TestModel]{})
+		assert.Error(t, err, "DeleteWhere should refuse an empty specification")
+
+		affected, err := repo.DeleteWhere(ctx, crud.Specification[TestModel]{}, crud.AllowFullTable())
+		assert.NoError(t, err, "DeleteWhere should run on the full table when allowed")
+		assert.Equal(t, int64(2), affected, "DeleteWhere should delete every record")
+	})
+
+	t.Run("gorm deleted at", func(t *testing.T) {
+		repo := crud.NewRepository[SoftDeleteModel](db)
+		_, err := repo.InsertMany(ctx, []SoftDeleteModel{{Name: "Alice"}, {Name: "Bob"}})
+		assert.NoError(t, err, "Failed to insert test data")
+
+		affected, err := repo.DeleteWhere(ctx, crud.Specification[SoftDeleteModel]{Filter: crud.Eq("name", "Alice")})
+		assert.NoError(t, err, "DeleteWhere should not return error")
+		assert.Equal(t, int64(1), affected, "DeleteWhere should soft delete the matching record")
+
+		var count int64
+		err = db.Model(&SoftDeleteModel{}).Count(&count).Error
+		assert.NoError(t, err, "Failed to count records")
+		assert.Equal(t, int64(1), count, "GORM should see the record as soft deleted")
+	})
+}
@@ -0,0 +1,143 @@
+package gocrud_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	crud "github.com/itsLeonB/go-crud"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func seedCursorData(t *testing.T, db *gorm.DB) {
+	now := time.Now()
+	for i := 1; i <= 7; i++ {
+		// Records 3 and 4 share a timestamp so the primary key tiebreaker is exercised
+		createdAt := now.Add(time.Duration(i) * time.Minute)
+		if i == 4 {
+			createdAt = now.Add(3 * time.Minute)
+		}
+		model := TestModel{Name: fmt.Sprintf("User%d", i), Email: fmt.Sprintf("user%d@example.com", i), Age: i, CreatedAt: createdAt}
+		err := db.Create(&model).Error
+		assert.NoError(t, err, "Failed to create test data")
+	}
+}
+
+func testModelNames(models []TestModel) []string {
+	names := make([]string, len(models))
+	for i, model := range models {
+		names[i] = model.Name
+	}
+	return names
+}
+
+func TestRepository_FindCursor(t *testing.T) {
+	db := setupTestDB(t)
+	seedCursorData(t, db)
+	repo := crud.NewRepository[TestModel](db)
+	ctx := context.Background()
+	spec := crud.Specification[TestModel]{}
+
+	first, err := repo.FindCursor(ctx, spec, crud.CursorRequest{Limit: 3})
+	assert.NoError(t, err, "FindCursor should not return error")
+	assert.Equal(t, []string{"User7", "User6", "User5"}, testModelNames(first.Items), "First page should be newest records")
+	assert.True(t, first.HasNext, "First page should have next page")
+	assert.False(t, first.HasPrev, "First page should not have previous page")
+	assert.Empty(t, first.PrevCursor, "First page should not have previous cursor")
+
+	second, err := repo.FindCursor(ctx, spec, crud.CursorRequest{After: first.NextCursor, Limit: 3})
+	assert.NoError(t, err, "FindCursor should not return error")
+	assert.Equal(t, []string{"User4", "User3", "User2"}, testModelNames(second.Items), "Second page should break timestamp ties by ID")
+	assert.True(t, second.HasNext, "Second page should have next page")
+	assert.True(t, second.HasPrev, "Second page should have previous page")
+
+	third, err := repo.FindCursor(ctx, spec, crud.CursorRequest{After: second.NextCursor, Limit: 3})
+	assert.NoError(t, err, "FindCursor should not return error")
+	assert.Equal(t, []string{"User1"}, testModelNames(third.Items), "Last page should contain remaining record")
+	assert.False(t, third.HasNext, "Last page should not have next page")
+	assert.Empty(t, third.NextCursor, "Last page should not have next cursor")
+
+	back, err := repo.FindCursor(ctx, spec, crud.CursorRequest{Before: third.PrevCursor, Limit: 3})
+	assert.NoError(t, err, "FindCursor backward should not return error")
+	assert.Equal(t, testModelNames(second.Items), testModelNames(back.Items), "Moving backward should return the previous page in order")
+	assert.True(t, back.HasPrev, "Previous page should have a page before it")
+
+	backToStart, err := repo.FindCursor(ctx, spec, crud.CursorRequest{Before: back.PrevCursor, Limit: 3})
+	assert.NoError(t, err, "FindCursor backward should not return error")
+	assert.Equal(t, testModelNames(first.Items), testModelNames(backToStart.Items), "Moving backward should reach the first page")
+	assert.False(t, backToStart.HasPrev, "First page should not have previous page")
+}
+
+func TestRepository_FindCursor_Sort(t *testing.T) {
+	db := setupTestDB(t)
+	seedCursorData(t, db)
+	repo := crud.NewRepository[TestModel](db)
+	ctx := context.Background()
+	spec := crud.Specification[TestModel]{}
+
+	t.Run("custom sort", func(t *testing.T) {
+		req := crud.CursorRequest{Limit: 4, Sort: []crud.Sort{{Field: "Age"}}}
+		first, err := repo.FindCursor(ctx, spec, req)
+		assert.NoError(t, err, "FindCursor should not return error")
+		assert.Equal(t, []string{"User1", "User2", "User3", "User4"}, testModelNames(first.Items), "Custom sort should be applied")
+
+		req.After = first.NextCursor
+		second, err := repo.FindCursor(ctx, spec, req)
+		assert.NoError(t, err, "FindCursor should not return error")
+		assert.Equal(t, []string{"User5", "User6", "User7"}, testModelNames(second.Items), "Custom sort should continue after cursor")
+	})
+
+	t.Run("cursor from another sort", func(t *testing.T) {
+		first, err := repo.FindCursor(ctx, spec, crud.CursorRequest{Limit: 2})
+		assert.NoError(t, err, "FindCursor should not return error")
+
+		_, err = repo.FindCursor(ctx, spec, crud.CursorRequest{After: first.NextCursor, Limit: 2, Sort: []crud.Sort{{Field: "name"}}})
+		assert.True(t, errors.Is(err, crud.ErrInvalidCursor), "Cursor should be rejected for a different sort")
+	})
+
+	t.Run("invalid sort field", func(t *testing.T) {
+		_, err := repo.FindCursor(ctx, spec, crud.CursorRequest{Sort: []crud.Sort{{Field: "name; DROP TABLE test_models"}}})
+		assert.Error(t, err, "FindCursor should reject invalid field names")
+
+		_, err = repo.FindCursor(ctx, spec, crud.CursorRequest{Sort: []crud.Sort{{Field: "unknown"}}})
+		assert.Error(t, err, "FindCursor should reject unknown fields")
+	})
+
+	t.Run("nullable sort field", func(t *testing.T) {
+		nullRepo := crud.NewRepository[NullTimeModel](db)
+		_, err := nullRepo.FindCursor(ctx, crud.Specification[NullTimeModel]{}, crud.CursorRequest{Sort: []crud.Sort{{Field: "deleted_at"}}})
+		assert.Error(t, err, "FindCursor should reject sort fields that may be NULL")
+	})
+
+	t.Run("both directions", func(t *testing.T) {
+		_, err := repo.FindCursor(ctx, spec, crud.CursorRequest{After: "a", Before: "b"})
+		assert.Error(t, err, "FindCursor should reject After and Before together")
+	})
+}
+
+func TestRepository_FindCursor_Signed(t *testing.T) {
+	db := setupTestDB(t)
+	seedCursorData(t, db)
+	repo := crud.NewRepository[TestModel](db, crud.WithCursorSecret([]byte("secret")))
+	ctx := context.Background()
+	spec := crud.Specification[TestModel]{}
+
+	first, err := repo.FindCursor(ctx, spec, crud.CursorRequest{Limit: 3})
+	assert.NoError(t, err, "FindCursor should not return error")
+
+	_, err = repo.FindCursor(ctx, spec, crud.CursorRequest{After: first.NextCursor, Limit: 3})
+	assert.NoError(t, err, "Signed cursor should be accepted")
+
+	tampered := first.NextCursor[:len(first.NextCursor)-2] + "xx"
+	_, err = repo.FindCursor(ctx, spec, crud.CursorRequest{After: tampered, Limit: 3})
+	assert.True(t, errors.Is(err, crud.ErrInvalidCursor), "Tampered cursor should be rejected")
+
+	unsigned, err := crud.NewRepository[TestModel](db).FindCursor(ctx, spec, crud.CursorRequest{Limit: 3})
+	assert.NoError(t, err, "FindCursor should not return error")
+
+	_, err = repo.FindCursor(ctx, spec, crud.CursorRequest{After: unsigned.NextCursor, Limit: 3})
+	assert.True(t, errors.Is(err, crud.ErrInvalidCursor), "Unsigned cursor should be rejected when a secret is configured")
+}
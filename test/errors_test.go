@@ -0,0 +1,170 @@
+package gocrud_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	crud "github.com/itsLeonB/go-crud"
+	"github.com/rotisserie/eris"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// ConstrainedParent and ConstrainedChild exercise constraint violations on SQLite
+type ConstrainedParent struct {
+	ID   uint    `gorm:"primaryKey"`
+	Code *string `gorm:"not null"`
+	Age  int     `gorm:"check:chk_parent_age,age >= 0"`
+}
+
+type ConstrainedChild struct {
+	ID       uint `gorm:"primaryKey"`
+	ParentID uint
+	Parent   ConstrainedParent
+}
+
+func setupErrorsTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:?_foreign_keys=on"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	assert.NoError(t, err, "Failed to connect to test database")
+
+	err = db.AutoMigrate(&TestModel{}, &ConstrainedParent{}, &ConstrainedChild{})
+	assert.NoError(t, err, "Failed to migrate test models")
+
+	return db
+}
+
+func TestRepository_ClassifiedErrors(t *testing.T) {
+	db := setupErrorsTestDB(t)
+	ctx := context.Background()
+
+	t.Run("unique violation", func(t *testing.T) {
+		repo := crud.NewRepository[TestModel](db)
+		_, err := repo.Insert(ctx, TestModel{Name: "Alice", Email: "alice@example.com"})
+		assert.NoError(t, err, "Failed to insert test data")
+
+		_, err = repo.Insert(ctx, TestModel{Name: "Alice 2", Email: "alice@example.com"})
+		assert.True(t, errors.Is(err, crud.ErrUniqueViolation), "Duplicate insert should match ErrUniqueViolation")
+
+		var dbErr *crud.DBError
+		assert.True(t, errors.As(err, &dbErr), "Duplicate insert should expose DBError")
+		assert.Equal(t, "test_models", dbErr.Table, "DBError should report the table")
+		assert.Equal(t, "email", dbErr.Column, "DBError should report the column")
+	})
+
+	t.Run("not null violation", func(t *testing.T) {
+		repo := crud.NewRepository[ConstrainedParent](db)
+		_, err := repo.Insert(ctx, ConstrainedParent{Age: 1})
+		assert.True(t, errors.Is(err, crud.ErrNotNullViolation), "Insert without code should match ErrNotNullViolation")
+
+		var dbErr *crud.DBError
+		assert.True(t, errors.As(err, &dbErr), "Not null violation should expose DBError")
+		assert.Equal(t, "code", dbErr.Column, "DBError should report the column")
+	})
+
+	t.Run("check violation", func(t *testing.T) {
+		repo := crud.NewRepository[ConstrainedParent](db)
+		code := "negative"
+		_, err := repo.Insert(ctx, ConstrainedParent{Code: &code, Age: -1})
+		assert.True(t, errors.Is(err, crud.ErrCheckViolation), "Negative age should match ErrCheckViolation")
+
+		var dbErr *crud.DBError
+		assert.True(t, errors.As(err, &dbErr), "Check violation should expose DBError")
+		assert.Equal(t, "chk_parent_age", dbErr.Constraint, "DBError should report the constraint")
+	})
+
+	t.Run("foreign key violation", func(t *testing.T) {
+		repo := crud.NewRepository[ConstrainedChild](db)
+		_, err := repo.Insert(ctx, ConstrainedChild{ParentID: 999})
+		assert.True(t, errors.Is(err, crud.ErrForeignKeyViolation), "Orphan child should match ErrForeignKeyViolation")
+	})
+
+	t.Run("unclassified error", func(t *testing.T) {
+		err := eris.New("something else")
+		assert.Equal(t, err, crud.ClassifyError(err), "ClassifyError should return unknown errors unchanged")
+		assert.Nil(t, crud.ClassifyError(nil), "ClassifyError should return nil for nil")
+	})
+}
+
+// fakePgError mimics *pgconn.PgError
+type fakePgError struct {
+	Code           string
+	TableName      string
+	ColumnName     string
+	ConstraintName string
+}
+
+func (e *fakePgError) Error() string    { return "pg error " + e.Code }
+func (e *fakePgError) SQLState() string { return e.Code }
+
+// fakeMySQLError mimics *mysql.MySQLError
+type fakeMySQLError struct {
+	Number  uint16
+	Message string
+}
+
+func (e *fakeMySQLError) Error() string { return fmt.Sprintf("Error %d: %s", e.Number, e.Message) }
+
+func TestClassifyError_Drivers(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		wantKind       error
+		wantColumn     string
+		wantConstraint string
+	}{
+		{"postgres unique", &fakePgError{Code: "23505", TableName: "users", ConstraintName: "users_email_key"}, crud.ErrUniqueViolation, "", "users_email_key"},
+		{"postgres not null", &fakePgError{Code: "23502", ColumnName: "name"}, crud.ErrNotNullViolation, "name", ""},
+		{"postgres serialization", &fakePgError{Code: "40001"}, crud.ErrSerializationFailure, "", ""},
+		{"postgres deadlock", &fakePgError{Code: "40P01"}, crud.ErrDeadlock, "", ""},
+		{"mysql duplicate", &fakeMySQLError{Number: 1062, Message: "Duplicate entry 'a@b.c' for key 'users.idx_email'"}, crud.ErrUniqueViolation, "", "users.idx_email"},
+		{"mysql foreign key", &fakeMySQLError{Number: 1452, Message: "Cannot add or update a child row: a foreign key constraint fails (`db`.`orders`, CONSTRAINT `fk_orders_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`))"}, crud.ErrForeignKeyViolation, "user_id", "fk_orders_user"},
+		{"mysql not null", &fakeMySQLError{Number: 1048, Message: "Column 'name' cannot be null"}, crud.ErrNotNullViolation, "name", ""},
+		{"mysql check", &fakeMySQLError{Number: 3819, Message: "Check constraint 'chk_age' is violated."}, crud.ErrCheckViolation, "", "chk_age"},
+		{"mysql deadlock", &fakeMySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"}, crud.ErrDeadlock, "", ""},
+		{"sqlite busy", errors.New("database is locked (5) (SQLITE_BUSY)"), crud.ErrDatabaseBusy, "", ""},
+		{"gorm translated", gorm.ErrDuplicatedKey, crud.ErrUniqueViolation, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := eris.Wrap(crud.ClassifyError(tt.err), "error inserting data")
+			assert.True(t, errors.Is(err, tt.wantKind), "Classified error should match its kind")
+			assert.True(t, errors.Is(err, tt.err), "Classified error should still match the driver error")
+
+			var dbErr *crud.DBError
+			assert.True(t, errors.As(err, &dbErr), "Classified error should expose DBError")
+			assert.Equal(t, tt.wantColumn, dbErr.Column, "DBError should report the column")
+			assert.Equal(t, tt.wantConstraint, dbErr.Constraint, "DBError should report the constraint")
+		})
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	assert.True(t, crud.IsRetryable(&fakePgError{Code: "40001"}), "Serialization failures should be retryable")
+	assert.True(t, crud.IsRetryable(eris.Wrap(&fakeMySQLError{Number: 1213}, "error updating data")), "Wrapped deadlocks should be retryable")
+	assert.False(t, crud.IsRetryable(&fakePgError{Code: "23505"}), "Unique violations should not be retryable")
+	assert.False(t, crud.IsRetryable(errors.New("boom")), "Unclassified errors should not be retryable")
+	assert.False(t, crud.IsRetryable(nil), "nil should not be retryable")
+
+	t.Run("sqlite busy", func(t *testing.T) {
+		// Immediate transactions take the write lock on BEGIN, and a zero busy timeout fails at once
+		db, err := gorm.Open(sqlite.Open(t.TempDir()+"/test.db?_txlock=immediate&_busy_timeout=0"), &gorm.Config{
+			Logger: logger.Default.LogMode(logger.Silent),
+		})
+		assert.NoError(t, err, "Failed to connect to test database")
+
+		holder := db.Begin()
+		assert.NoError(t, holder.Error, "Failed to begin the lock holding transaction")
+		defer holder.Rollback()
+
+		blocked := db.Begin()
+		assert.ErrorIs(t, crud.ClassifyError(blocked.Error), crud.ErrDatabaseBusy, "A locked database should be classified as busy")
+		assert.True(t, crud.IsRetryable(blocked.Error), "A locked database should be retryable")
+	})
+}
@@ -0,0 +1,112 @@
+package gocrud_test
+
+import (
+	"context"
+	"testing"
+
+	crud "github.com/itsLeonB/go-crud"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func seedFilterData(t *testing.T, db *gorm.DB) {
+	testModels := []TestModel{
+		{Name: "Alice", Email: "alice@example.com", Age: 25},
+		{Name: "Bob", Email: "bob@example.com", Age: 30},
+		{Name: "Charlie", Email: "charlie@example.com", Age: 35},
+		{Name: "alfred", Email: "alfred@example.com", Age: 40},
+		{Name: "Dave", Age: 45},
+	}
+	err := db.Create(&testModels).Error
+	assert.NoError(t, err, "Failed to create test data")
+
+	// Email is NULL for Dave
+	err = db.Model(&TestModel{}).Where("name = ?", "Dave").Update("email", nil).Error
+	assert.NoError(t, err, "Failed to clear email")
+}
+
+func TestWhereFilter(t *testing.T) {
+	db := setupScopesTestDB(t)
+	seedFilterData(t, db)
+
+	tests := []struct {
+		name      string
+		filter    crud.Filter
+		wantNames []string
+	}{
+		{"eq", crud.Eq("name", "Bob"), []string{"Bob"}},
+		{"eq by struct field name", crud.Eq("Age", 30), []string{"Bob"}},
+		{"ne", crud.Ne("age", 25), []string{"Bob", "Charlie", "alfred", "Dave"}},
+		{"gt", crud.Gt("age", 35), []string{"alfred", "Dave"}},
+		{"gte", crud.Gte("age", 35), []string{"Charlie", "alfred", "Dave"}},
+		{"lt", crud.Lt("age", 30), []string{"Alice"}},
+		{"lte", crud.Lte("age", 30), []string{"Alice", "Bob"}},
+		{"in variadic", crud.In("name", "Alice", "Bob"), []string{"Alice", "Bob"}},
+		{"in slice", crud.In("age", []int{25, 45}), []string{"Alice", "Dave"}},
+		{"in empty", crud.In("age"), []string{}},
+		{"not in", crud.NotIn("age", []int{25, 30, 35}), []string{"alfred", "Dave"}},
+		{"not in empty", crud.NotIn("age"), []string{"Alice", "Bob", "Charlie", "alfred", "Dave"}},
+		{"like", crud.Like("name", "%ice"), []string{"Alice"}},
+		{"ilike", crud.ILike("name", "AL%"), []string{"Alice", "alfred"}},
+		{"is null", crud.IsNull("email"), []string{"Dave"}},
+		{"not null", crud.NotNull("email"), []string{"Alice", "Bob", "Charlie", "alfred"}},
+		{"between", crud.Between("age", 30, 40), []string{"Bob", "Charlie", "alfred"}},
+		{"and", crud.And(crud.Gt("age", 25), crud.Lt("age", 40)), []string{"Bob", "Charlie"}},
+		{"or", crud.Or(crud.Eq("name", "Alice"), crud.Eq("age", 45)), []string{"Alice", "Dave"}},
+		{"not", crud.Not(crud.In("name", "Alice", "Bob")), []string{"Charlie", "alfred", "Dave"}},
+		{"or not in empty", crud.Or(crud.NotIn("name"), crud.Eq("name", "nope")), []string{"Alice", "Bob", "Charlie", "alfred", "Dave"}},
+		{"not not in empty", crud.Not(crud.NotIn("name")), []string{}},
+		{"empty or", crud.Or(), []string{"Alice", "Bob", "Charlie", "alfred", "Dave"}},
+		{"not empty or", crud.Not(crud.Or()), []string{}},
+		{
+			"nested",
+			crud.And(crud.NotNull("email"), crud.Or(crud.Lt("age", 30), crud.Gt("age", 35))),
+			[]string{"Alice", "alfred"},
+		},
+		{"nil filters ignored", crud.And(nil, crud.Eq("name", "Bob"), nil), []string{"Bob"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var results []TestModel
+			err := db.Scopes(crud.WhereFilter[TestModel](tt.filter)).Order("id").Find(&results).Error
+			assert.NoError(t, err, "WhereFilter should not return error")
+			assert.Equal(t, tt.wantNames, testModelNames(results), "WhereFilter should return expected records")
+		})
+	}
+
+	t.Run("invalid field name", func(t *testing.T) {
+		var results []TestModel
+		err := db.Scopes(crud.WhereFilter[TestModel](crud.Eq("name'; DROP TABLE test_models; --", 1))).Find(&results).Error
+		assert.Error(t, err, "WhereFilter should reject invalid field names")
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		var results []TestModel
+		err := db.Scopes(crud.WhereFilter[TestModel](crud.Or(crud.Eq("name", "Bob"), crud.Eq("missing", 1)))).Find(&results).Error
+		assert.Error(t, err, "WhereFilter should reject fields missing from the schema")
+	})
+}
+
+func TestRepository_FindAll_Filter(t *testing.T) {
+	db := setupScopesTestDB(t)
+	seedFilterData(t, db)
+	repo := crud.NewRepository[TestModel](db)
+	ctx := context.Background()
+
+	t.Run("composes with model", func(t *testing.T) {
+		spec := crud.Specification[TestModel]{
+			Model:  TestModel{Name: "Alice"},
+			Filter: crud.Or(crud.Eq("age", 25), crud.Eq("age", 45)),
+		}
+		results, err := repo.FindAll(ctx, spec)
+		assert.NoError(t, err, "FindAll should not return error")
+		assert.Equal(t, []string{"Alice"}, testModelNames(results), "Or filter should be grouped and combined with Model using AND")
+	})
+
+	t.Run("count uses filter", func(t *testing.T) {
+		page, err := repo.FindPage(ctx, crud.Specification[TestModel]{Filter: crud.Gte("age", 35)}, crud.PageRequest{Limit: 1})
+		assert.NoError(t, err, "FindPage should not return error")
+		assert.Equal(t, int64(3), page.Total, "FindPage total should apply the filter")
+	})
+}
@@ -0,0 +1,135 @@
+package gocrud_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	crud "github.com/itsLeonB/go-crud"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository_Iterate(t *testing.T) {
+	db := setupScopesTestDB(t)
+	seedFilterData(t, db)
+	repo := crud.NewRepository[TestModel](db)
+	ctx := context.Background()
+
+	t.Run("all rows", func(t *testing.T) {
+		var names []string
+		for model, err := range repo.Iterate(ctx, crud.Specification[TestModel]{Filter: crud.Gte("age", 30), OrderBy: []crud.Sort{{Field: "age"}}}) {
+			assert.NoError(t, err, "Iterate should not return error")
+			names = append(names, model.Name)
+		}
+		assert.Equal(t, []string{"Bob", "Charlie", "alfred", "Dave"}, names, "Iterate should stream matching records in order")
+	})
+
+	t.Run("early break", func(t *testing.T) {
+		count := 0
+		for _, err := range repo.Iterate(ctx, crud.Specification[TestModel]{}) {
+			assert.NoError(t, err, "Iterate should not return error")
+			count++
+			if count == 2 {
+				break
+			}
+		}
+		assert.Equal(t, 2, count, "Iterate should stop when the loop breaks")
+
+		// The rows must have been released for further queries to succeed
+		total, err := repo.Count(ctx, crud.Specification[TestModel]{})
+		assert.NoError(t, err, "Count should not return error after breaking out of Iterate")
+		assert.Equal(t, int64(5), total, "Count should see all records")
+	})
+
+	t.Run("invalid spec", func(t *testing.T) {
+		var errs []error
+		for _, err := range repo.Iterate(ctx, crud.Specification[TestModel]{Filter: crud.Eq("missing", 1)}) {
+			errs = append(errs, err)
+		}
+		assert.Len(t, errs, 1, "Iterate should yield a single error")
+		assert.Error(t, errs[0], "Iterate should yield query errors")
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		var lastErr error
+		for _, err := range repo.Iterate(canceled, crud.Specification[TestModel]{}) {
+			lastErr = err
+		}
+		assert.Error(t, lastErr, "Iterate should fail when the context is canceled")
+	})
+}
+
+func TestRepository_FindInBatches(t *testing.T) {
+	db := setupScopesTestDB(t)
+	seedFilterData(t, db)
+	repo := crud.NewRepository[TestModel](db)
+	ctx := context.Background()
+
+	t.Run("walks all batches", func(t *testing.T) {
+		var batches [][]string
+		err := repo.FindInBatches(ctx, crud.Specification[TestModel]{}, 2, func(batch []TestModel) error {
+			batches = append(batches, testModelNames(batch))
+			return nil
+		})
+		assert.NoError(t, err, "FindInBatches should not return error")
+		assert.Equal(t, [][]string{{"Alice", "Bob"}, {"Charlie", "alfred"}, {"Dave"}}, batches, "FindInBatches should walk the primary key in batches")
+	})
+
+	t.Run("applies filters", func(t *testing.T) {
+		var names []string
+		err := repo.FindInBatches(ctx, crud.Specification[TestModel]{Filter: crud.NotNull("email")}, 3, func(batch []TestModel) error {
+			names = append(names, testModelNames(batch)...)
+			return nil
+		})
+		assert.NoError(t, err, "FindInBatches should not return error")
+		assert.Equal(t, []string{"Alice", "Bob", "Charlie", "alfred"}, names, "FindInBatches should apply the specification")
+	})
+
+	t.Run("stops on callback error", func(t *testing.T) {
+		errStop := errors.New("stop")
+		calls := 0
+		err := repo.FindInBatches(ctx, crud.Specification[TestModel]{}, 2, func(batch []TestModel) error {
+			calls++
+			return errStop
+		})
+		assert.ErrorIs(t, err, errStop, "FindInBatches should return the callback error")
+		assert.Equal(t, 1, calls, "FindInBatches should stop after the callback fails")
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		canceled, cancel := context.WithCancel(ctx)
+		calls := 0
+		err := repo.FindInBatches(canceled, crud.Specification[TestModel]{}, 2, func(batch []TestModel) error {
+			calls++
+			cancel()
+			return nil
+		})
+		assert.ErrorIs(t, err, context.Canceled, "FindInBatches should stop when the context is canceled")
+		assert.Equal(t, 1, calls, "FindInBatches should not load further batches after cancellation")
+	})
+
+	t.Run("within transaction", func(t *testing.T) {
+		transactor := crud.NewTransactor(db)
+		err := transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+			_, err := repo.Insert(txCtx, TestModel{Name: "Eve", Email: "eve@example.com", Age: 50})
+			assert.NoError(t, err, "Insert should not return error")
+
+			var names []string
+			err = repo.FindInBatches(txCtx, crud.Specification[TestModel]{Filter: crud.Gte("age", 45)}, 10, func(batch []TestModel) error {
+				names = append(names, testModelNames(batch)...)
+				return nil
+			})
+			assert.Equal(t, []string{"Dave", "Eve"}, names, "FindInBatches should see uncommitted rows of the transaction")
+			return err
+		})
+		assert.NoError(t, err, "Transaction should not return error")
+	})
+
+	t.Run("invalid batch size", func(t *testing.T) {
+		err := repo.FindInBatches(ctx, crud.Specification[TestModel]{}, 0, func([]TestModel) error { return nil })
+		assert.Error(t, err, "FindInBatches should reject a non-positive batch size")
+	})
+}
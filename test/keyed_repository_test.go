@@ -0,0 +1,84 @@
+package gocrud_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	crud "github.com/itsLeonB/go-crud"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyedRepository(t *testing.T) {
+	db := setupSoftDeleteTestDB(t)
+	repo := crud.NewKeyedRepository[NullTimeModel, uint](db, crud.WithIDChunkSize(2))
+	ctx := context.Background()
+
+	models, err := repo.InsertMany(ctx, []NullTimeModel{{Name: "Alice"}, {Name: "Bob"}, {Name: "Charlie"}, {Name: "Dave"}})
+	assert.NoError(t, err, "Failed to insert test data")
+
+	t.Run("find by id", func(t *testing.T) {
+		result, err := repo.FindByID(ctx, models[1].ID)
+		assert.NoError(t, err, "FindByID should not return error")
+		assert.Equal(t, "Bob", result.Name, "FindByID should return the matching record")
+
+		_, err = repo.FindByID(ctx, 999)
+		assert.True(t, errors.Is(err, crud.ErrNotFound), "FindByID should return ErrNotFound for missing records")
+	})
+
+	t.Run("find by ids keeps input order across chunks", func(t *testing.T) {
+		ids := []uint{models[3].ID, 999, models[0].ID, models[2].ID, models[1].ID}
+		results, err := repo.FindByIDs(ctx, ids)
+		assert.NoError(t, err, "FindByIDs should not return error")
+
+		names := make([]string, len(results))
+		for i, result := range results {
+			names[i] = result.Name
+		}
+		assert.Equal(t, []string{"Dave", "Alice", "Charlie", "Bob"}, names, "FindByIDs should follow input order and skip missing IDs")
+
+		empty, err := repo.FindByIDs(ctx, nil)
+		assert.NoError(t, err, "FindByIDs should not return error for empty input")
+		assert.Empty(t, empty, "FindByIDs should return no records for empty input")
+	})
+
+	t.Run("exists by id", func(t *testing.T) {
+		exists, err := repo.ExistsByID(ctx, models[0].ID)
+		assert.NoError(t, err, "ExistsByID should not return error")
+		assert.True(t, exists, "ExistsByID should find existing records")
+
+		exists, err = repo.ExistsByID(ctx, 999)
+		assert.NoError(t, err, "ExistsByID should not return error")
+		assert.False(t, exists, "ExistsByID should not find missing records")
+	})
+
+	t.Run("soft deleted records are hidden", func(t *testing.T) {
+		err := repo.SoftDelete(ctx, models[2])
+		assert.NoError(t, err, "SoftDelete should not return error")
+
+		_, err = repo.FindByID(ctx, models[2].ID)
+		assert.True(t, errors.Is(err, crud.ErrNotFound), "FindByID should exclude soft-deleted records")
+
+		exists, err := repo.ExistsByID(ctx, models[2].ID)
+		assert.NoError(t, err, "ExistsByID should not return error")
+		assert.False(t, exists, "ExistsByID should exclude soft-deleted records")
+	})
+
+	t.Run("delete by id", func(t *testing.T) {
+		err := repo.DeleteByID(ctx, models[3].ID)
+		assert.NoError(t, err, "DeleteByID should not return error")
+
+		exists, err := repo.ExistsByID(ctx, models[3].ID)
+		assert.NoError(t, err, "ExistsByID should not return error")
+		assert.False(t, exists, "DeleteByID should remove the record")
+
+		err = repo.DeleteByID(ctx, models[3].ID)
+		assert.True(t, errors.Is(err, crud.ErrNotFound), "DeleteByID should return ErrNotFound for missing records")
+	})
+
+	t.Run("mismatched id type", func(t *testing.T) {
+		wrongRepo := crud.NewKeyedRepository[NullTimeModel, string](db)
+		_, err := wrongRepo.FindByID(ctx, "1")
+		assert.Error(t, err, "FindByID should reject an ID type that does not match the primary key")
+	})
+}
@@ -0,0 +1,68 @@
+package gocrud_test
+
+import (
+	"context"
+	"testing"
+
+	crud "github.com/itsLeonB/go-crud"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func TestWithLock(t *testing.T) {
+	db := setupScopesTestDB(t)
+
+	tests := []struct {
+		name string
+		lock crud.Lock
+		want clause.Locking
+	}{
+		{"for update", crud.Lock{Strength: crud.LockForUpdate}, clause.Locking{Strength: "UPDATE"}},
+		{"skip locked", crud.Lock{Strength: crud.LockForUpdate, Wait: crud.LockSkipLocked}, clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}},
+		{"share nowait", crud.Lock{Strength: crud.LockForShare, Wait: crud.LockNoWait}, clause.Locking{Strength: "SHARE", Options: "NOWAIT"}},
+		{"of table", crud.Lock{Strength: crud.LockForNoKeyUpdate, Of: "test_models"}, clause.Locking{Strength: "NO KEY UPDATE", Table: clause.Table{Name: "test_models"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var results []TestModel
+			stmt := db.Session(&gorm.Session{DryRun: true}).Scopes(crud.Locking(tt.lock)).Find(&results).Statement
+			assert.NoError(t, stmt.Error, "Locking should not return error")
+			assert.Equal(t, tt.want, stmt.Clauses["FOR"].Expression, "Locking should add the locking clause")
+		})
+	}
+
+	t.Run("zero lock", func(t *testing.T) {
+		var results []TestModel
+		stmt := db.Session(&gorm.Session{DryRun: true}).Scopes(crud.Locking(crud.Lock{})).Find(&results).Statement
+		assert.NotContains(t, stmt.Clauses, "FOR", "A zero Lock should not add a locking clause")
+	})
+
+	t.Run("invalid lock", func(t *testing.T) {
+		var results []TestModel
+		err := db.Scopes(crud.Locking(crud.Lock{Strength: "UPDATE; DROP TABLE test_models"})).Find(&results).Error
+		assert.Error(t, err, "Locking should reject unknown strengths")
+
+		err = db.Scopes(crud.Locking(crud.Lock{Strength: crud.LockForUpdate, Wait: "WAIT 5"})).Find(&results).Error
+		assert.Error(t, err, "Locking should reject unknown wait policies")
+
+		err = db.Scopes(crud.Locking(crud.Lock{Strength: crud.LockForUpdate, Of: "test_models; --"})).Find(&results).Error
+		assert.Error(t, err, "Locking should reject invalid table names")
+	})
+}
+
+func TestRepository_FindAll_Lock(t *testing.T) {
+	db := setupScopesTestDB(t)
+	seedFilterData(t, db)
+	repo := crud.NewRepository[TestModel](db)
+	ctx := context.Background()
+
+	// SQLite has no row-level locks, so the locking clause is dropped rather than failing the query
+	results, err := repo.FindAll(ctx, crud.Specification[TestModel]{
+		Filter: crud.Eq("name", "Bob"),
+		Lock:   crud.Lock{Strength: crud.LockForUpdate, Wait: crud.LockSkipLocked},
+	})
+	assert.NoError(t, err, "FindAll should not return error on SQLite")
+	assert.Equal(t, []string{"Bob"}, testModelNames(results), "FindAll should return matching records")
+}
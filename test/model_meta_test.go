@@ -0,0 +1,130 @@
+package gocrud_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	crud "github.com/itsLeonB/go-crud"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// LegacyModel uses non-standard timestamp column names
+type LegacyModel struct {
+	ID         uint      `gorm:"primaryKey"`
+	Name       string    `gorm:"not null"`
+	InsertedOn time.Time `gorm:"autoCreateTime"`
+	RemovedAt  sql.NullTime
+}
+
+// PlainModel has no timestamp columns at all
+type PlainModel struct {
+	ID   uint   `gorm:"primaryKey"`
+	Name string `gorm:"not null"`
+}
+
+func setupModelMetaTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	assert.NoError(t, err, "Failed to connect to test database")
+
+	err = db.AutoMigrate(&LegacyModel{}, &PlainModel{}, &SoftDeleteModel{})
+	assert.NoError(t, err, "Failed to migrate test models")
+
+	return db
+}
+
+func TestRepository_DetectedDefaultOrder(t *testing.T) {
+	db := setupModelMetaTestDB(t)
+	ctx := context.Background()
+
+	t.Run("creation column from schema", func(t *testing.T) {
+		now := time.Now()
+		models := []LegacyModel{
+			{Name: "Old", InsertedOn: now.Add(-time.Hour)},
+			{Name: "New", InsertedOn: now},
+			{Name: "Middle", InsertedOn: now.Add(-time.Minute)},
+		}
+		err := db.Create(&models).Error
+		assert.NoError(t, err, "Failed to create test data")
+
+		results, err := crud.NewRepository[LegacyModel](db).FindAll(ctx, crud.Specification[LegacyModel]{})
+		assert.NoError(t, err, "FindAll should not return error")
+		assert.Equal(t, "New", results[0].Name, "FindAll should order by the creation column")
+		assert.Equal(t, "Old", results[2].Name, "FindAll should order by the creation column")
+	})
+
+	t.Run("primary key fallback", func(t *testing.T) {
+		err := db.Create(&[]PlainModel{{Name: "First"}, {Name: "Second"}}).Error
+		assert.NoError(t, err, "Failed to create test data")
+
+		results, err := crud.NewRepository[PlainModel](db).FindAll(ctx, crud.Specification[PlainModel]{})
+		assert.NoError(t, err, "FindAll should not fail for models without timestamps")
+		assert.Equal(t, "Second", results[0].Name, "FindAll should order by primary key descending")
+
+		page, err := crud.NewRepository[PlainModel](db).FindCursor(ctx, crud.Specification[PlainModel]{}, crud.CursorRequest{Limit: 1})
+		assert.NoError(t, err, "FindCursor should not fail for models without timestamps")
+		assert.Equal(t, "Second", page.Items[0].Name, "FindCursor should order by primary key descending")
+	})
+
+	t.Run("configured default order", func(t *testing.T) {
+		repo := crud.NewRepository[PlainModel](db, crud.WithDefaultOrder(crud.Sort{Field: "Name"}))
+		results, err := repo.FindAll(ctx, crud.Specification[PlainModel]{})
+		assert.NoError(t, err, "FindAll should not return error")
+		assert.Equal(t, "First", results[0].Name, "FindAll should use the configured default order")
+	})
+
+	t.Run("invalid configured default order", func(t *testing.T) {
+		repo := crud.NewRepository[PlainModel](db, crud.WithDefaultOrder(crud.Sort{Field: "created_at"}))
+		_, err := repo.FindAll(ctx, crud.Specification[PlainModel]{})
+		assert.Error(t, err, "FindAll should reject default order fields missing from the schema")
+	})
+}
+
+func TestRepository_DeletedColumn(t *testing.T) {
+	db := setupModelMetaTestDB(t)
+	ctx := context.Background()
+
+	models := []LegacyModel{
+		{Name: "Active"},
+		{Name: "Removed", RemovedAt: sql.NullTime{Time: time.Now(), Valid: true}},
+	}
+	err := db.Create(&models).Error
+	assert.NoError(t, err, "Failed to create test data")
+
+	t.Run("configured deleted column", func(t *testing.T) {
+		repo := crud.NewRepository[LegacyModel](db, crud.WithDeletedColumn("removed_at"))
+
+		active, err := repo.FindAll(ctx, crud.Specification[LegacyModel]{DeletedFilter: crud.ExcludeDeleted})
+		assert.NoError(t, err, "FindAll should not return error")
+		assert.Len(t, active, 1, "ExcludeDeleted should use the configured column")
+		assert.Equal(t, "Active", active[0].Name, "ExcludeDeleted should return active records")
+
+		removed, err := repo.FindAll(ctx, crud.Specification[LegacyModel]{DeletedFilter: crud.OnlyDeleted})
+		assert.NoError(t, err, "FindAll should not return error")
+		assert.Len(t, removed, 1, "OnlyDeleted should use the configured column")
+		assert.Equal(t, "Removed", removed[0].Name, "OnlyDeleted should return removed records")
+	})
+
+	t.Run("detected gorm.DeletedAt column", func(t *testing.T) {
+		err := db.Create(&SoftDeleteModel{Name: "Soft"}).Error
+		assert.NoError(t, err, "Failed to create test data")
+
+		results, err := crud.NewRepository[SoftDeleteModel](db).FindAll(ctx, crud.Specification[SoftDeleteModel]{DeletedFilter: crud.ExcludeDeleted})
+		assert.NoError(t, err, "FindAll should not return error")
+		assert.Len(t, results, 1, "ExcludeDeleted should use the detected column")
+	})
+
+	t.Run("model without deleted column", func(t *testing.T) {
+		_, err := crud.NewRepository[PlainModel](db).FindAll(ctx, crud.Specification[PlainModel]{DeletedFilter: crud.ExcludeDeleted})
+		assert.Error(t, err, "FindAll should report that the model has no deleted column")
+
+		_, err = crud.NewRepository[PlainModel](db).FindAll(ctx, crud.Specification[PlainModel]{})
+		assert.NoError(t, err, "FindAll without DeletedFilter should not need a deleted column")
+	})
+}
@@ -0,0 +1,102 @@
+package gocrud_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	crud "github.com/itsLeonB/go-crud"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestRepository_UpdateFields(t *testing.T) {
+	db := setupTestDB(t)
+	repo := crud.NewRepository[TestModel](db)
+	ctx := context.Background()
+
+	inserted, err := repo.Insert(ctx, TestModel{Name: "Alice", Email: "alice@example.com", Age: 25})
+	assert.NoError(t, err, "Failed to insert test data")
+	insertedAt := inserted.UpdatedAt
+
+	// A concurrent writer changes the email after we loaded the record
+	err = db.Model(&TestModel{}).Where("id = ?", inserted.ID).Update("email", "alice@example.org").Error
+	assert.NoError(t, err, "Failed to update email")
+	time.Sleep(10 * time.Millisecond)
+
+	stale := inserted
+	stale.Age = 0
+	stale.Name = "Alicia"
+	updated, err := repo.UpdateFields(ctx, stale, "Age", "name")
+	assert.NoError(t, err, "UpdateFields should not return error")
+	assert.True(t, updated.UpdatedAt.After(insertedAt), "UpdateFields should bump UpdatedAt")
+
+	var stored TestModel
+	err = db.First(&stored, inserted.ID).Error
+	assert.NoError(t, err, "Failed to load record")
+	assert.Equal(t, "Alicia", stored.Name, "UpdateFields should write named fields")
+	assert.Equal(t, 0, stored.Age, "UpdateFields should write zero values of named fields")
+	assert.Equal(t, "alice@example.org", stored.Email, "UpdateFields should not overwrite other columns")
+
+	t.Run("refresh", func(t *testing.T) {
+		refreshing := crud.NewRepository[TestModel](db, crud.WithRefreshOnUpdate())
+		updated, err := refreshing.UpdateFields(ctx, stale, "age")
+		assert.NoError(t, err, "UpdateFields should not return error")
+		assert.Equal(t, "alice@example.org", updated.Email, "WithRefreshOnUpdate should reload the record")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := repo.UpdateFields(ctx, TestModel{ID: 999, Name: "Nobody"}, "name")
+		assert.True(t, errors.Is(err, crud.ErrNotFound), "UpdateFields should return ErrNotFound when no record was updated")
+		assert.False(t, errors.Is(err, crud.ErrStaleEntity), "UpdateFields should not report unversioned models as stale")
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		_, err := repo.UpdateFields(ctx, stale)
+		assert.Error(t, err, "UpdateFields should require fields")
+
+		_, err = repo.UpdateFields(ctx, TestModel{Name: "No ID"}, "name")
+		assert.Error(t, err, "UpdateFields should require a primary key value")
+
+		_, err = repo.UpdateFields(ctx, stale, "unknown")
+		assert.Error(t, err, "UpdateFields should reject unknown fields")
+
+		_, err = repo.UpdateFields(ctx, stale, "id")
+		assert.Error(t, err, "UpdateFields should reject the primary key")
+	})
+}
+
+func TestRepository_UpdateMap(t *testing.T) {
+	db := setupSoftDeleteTestDB(t)
+	repo := crud.NewRepository[TestModel](db)
+	ctx := context.Background()
+
+	inserted, err := repo.Insert(ctx, TestModel{Name: "Alice", Email: "alice@example.com", Age: 25})
+	assert.NoError(t, err, "Failed to insert test data")
+	time.Sleep(10 * time.Millisecond)
+
+	updated, err := repo.UpdateMap(ctx, inserted.ID, map[string]any{"Age": gorm.Expr("age + ?", 1), "name": "Alicia"})
+	assert.NoError(t, err, "UpdateMap should not return error")
+	assert.Equal(t, 26, updated.Age, "UpdateMap should return the refreshed record")
+	assert.Equal(t, "Alicia", updated.Name, "UpdateMap should write the given columns")
+	assert.Equal(t, "alice@example.com", updated.Email, "UpdateMap should not overwrite other columns")
+	assert.True(t, updated.UpdatedAt.After(inserted.UpdatedAt), "UpdateMap should bump UpdatedAt")
+
+	_, err = repo.UpdateMap(ctx, uint(999), map[string]any{"name": "Nobody"})
+	assert.True(t, errors.Is(err, crud.ErrNotFound), "UpdateMap should return ErrNotFound when no record was updated")
+
+	_, err = repo.UpdateMap(ctx, inserted.ID, map[string]any{"name = 'x', age": 1})
+	assert.Error(t, err, "UpdateMap should reject invalid column names")
+
+	t.Run("soft deleted", func(t *testing.T) {
+		softRepo := crud.NewRepository[NullTimeModel](db)
+		model, err := softRepo.Insert(ctx, NullTimeModel{Name: "Bob"})
+		assert.NoError(t, err, "Failed to insert test data")
+		err = softRepo.SoftDelete(ctx, model)
+		assert.NoError(t, err, "SoftDelete should not return error")
+
+		_, err = softRepo.UpdateMap(ctx, model.ID, map[string]any{"name": "Robert"})
+		assert.True(t, errors.Is(err, crud.ErrNotFound), "UpdateMap should not update soft-deleted records")
+	})
+}
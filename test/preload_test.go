@@ -0,0 +1,158 @@
+package gocrud_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	crud "github.com/itsLeonB/go-crud"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type Customer struct {
+	ID     uint `gorm:"primaryKey"`
+	Name   string
+	Orders []Order
+}
+
+type Order struct {
+	ID         uint `gorm:"primaryKey"`
+	CustomerID uint
+	Customer   *Customer
+	Status     string
+	Total      int
+	Items      []OrderItem
+	DeletedAt  gorm.DeletedAt
+}
+
+type OrderItem struct {
+	ID      uint `gorm:"primaryKey"`
+	OrderID uint
+	Sku     string
+}
+
+func setupPreloadTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	assert.NoError(t, err, "Failed to connect to test database")
+
+	err = db.AutoMigrate(&Customer{}, &Order{}, &OrderItem{})
+	assert.NoError(t, err, "Failed to migrate test models")
+
+	customers := []Customer{
+		{Name: "Alice", Orders: []Order{
+			{Status: "active", Total: 10, Items: []OrderItem{{Sku: "A1"}, {Sku: "A2"}}},
+			{Status: "active", Total: 30},
+			{Status: "cancelled", Total: 50},
+			{Status: "active", Total: 20, Items: []OrderItem{{Sku: "B1"}}},
+			{Status: "active", Total: 40},
+		}},
+		{Name: "Bob", Orders: []Order{
+			{Status: "active", Total: 5},
+			{Status: "cancelled", Total: 15},
+		}},
+	}
+	err = db.Create(&customers).Error
+	assert.NoError(t, err, "Failed to create test data")
+
+	// A soft-deleted order must neither be loaded nor count towards the limit
+	err = db.Where("total = ?", 40).Delete(&Order{}).Error
+	assert.NoError(t, err, "Failed to soft delete order")
+
+	return db
+}
+
+func orderTotals(orders []Order) []int {
+	totals := make([]int, len(orders))
+	for i, order := range orders {
+		totals[i] = order.Total
+	}
+	return totals
+}
+
+func TestRepository_Preloads(t *testing.T) {
+	db := setupPreloadTestDB(t)
+	repo := crud.NewRepository[Customer](db)
+	ctx := context.Background()
+	byName := []crud.Sort{{Field: "name"}}
+
+	t.Run("filter, order and limit per parent", func(t *testing.T) {
+		customers, err := repo.FindAll(ctx, crud.Specification[Customer]{
+			OrderBy: byName,
+			Preloads: []crud.Preload{{
+				Relation: "Orders",
+				Filter:   crud.Eq("status", "active"),
+				OrderBy:  []crud.Sort{{Field: "Total", Desc: true}},
+				Limit:    2,
+			}},
+		})
+		assert.NoError(t, err, "FindAll should not return error")
+		assert.Equal(t, []int{30, 20}, orderTotals(customers[0].Orders), "Preload should keep the top orders of Alice")
+		assert.Equal(t, []int{5}, orderTotals(customers[1].Orders), "Preload should apply the limit to each customer separately")
+	})
+
+	t.Run("limit ranks only the parents' records", func(t *testing.T) {
+		var queries []string
+		session := db.Session(&gorm.Session{})
+		err := session.Callback().Query().After("gorm:query").Register("test:capture_preload", func(tx *gorm.DB) {
+			if sql := tx.Statement.SQL.String(); strings.HasPrefix(sql, "SELECT * FROM (") {
+				queries = append(queries, sql)
+			}
+		})
+		assert.NoError(t, err, "Failed to register query callback")
+		defer session.Callback().Query().Remove("test:capture_preload")
+
+		_, err = repo.FindAll(ctx, crud.Specification[Customer]{
+			Preloads: []crud.Preload{{Relation: "Orders", Limit: 1}},
+		})
+		assert.NoError(t, err, "FindAll should not return error")
+		assert.Len(t, queries, 1, "Preload should run one query")
+		inner := queries[0][:strings.LastIndex(queries[0], ") AS ")]
+		assert.Contains(t, inner, "IN (", "The ranking subquery should be restricted to the parents' keys")
+	})
+
+	t.Run("nested and select", func(t *testing.T) {
+		customer, err := repo.FindOne(ctx, crud.Specification[Customer]{
+			Model: Customer{Name: "Alice"},
+			Preloads: []crud.Preload{{
+				Relation: "Orders",
+				OrderBy:  []crud.Sort{{Field: "total"}},
+				Select:   []string{"total"},
+				Preloads: []crud.Preload{{Relation: "Items", Filter: crud.Like("sku", "A%")}},
+			}},
+		})
+		assert.NoError(t, err, "FindOne should not return error")
+		assert.Equal(t, []int{10, 20, 30, 50}, orderTotals(customer.Orders), "Preload should load every order that is not deleted")
+		assert.Empty(t, customer.Orders[0].Status, "Select should leave other columns unloaded")
+		assert.Len(t, customer.Orders[0].Items, 2, "Nested preload should load matching items")
+		assert.Empty(t, customer.Orders[1].Items, "Nested preload should apply its filter")
+	})
+
+	t.Run("belongs to", func(t *testing.T) {
+		orderRepo := crud.NewRepository[Order](db)
+		orders, err := orderRepo.FindAll(ctx, crud.Specification[Order]{
+			Filter:   crud.Eq("total", 5),
+			Preloads: []crud.Preload{{Relation: "Customer", Select: []string{"name"}}},
+		})
+		assert.NoError(t, err, "FindAll should not return error")
+		assert.Equal(t, "Bob", orders[0].Customer.Name, "Preload should load belongs-to relations")
+
+		_, err = orderRepo.FindAll(ctx, crud.Specification[Order]{Preloads: []crud.Preload{{Relation: "Customer", Limit: 1}}})
+		assert.Error(t, err, "Preload should reject a limit on belongs-to relations")
+	})
+
+	t.Run("invalid preloads", func(t *testing.T) {
+		_, err := repo.FindAll(ctx, crud.Specification[Customer]{Preloads: []crud.Preload{{Relation: "Invoices"}}})
+		assert.Error(t, err, "Preload should reject unknown relations")
+
+		_, err = repo.FindAll(ctx, crud.Specification[Customer]{Preloads: []crud.Preload{{Relation: "Orders", Preloads: []crud.Preload{{Relation: "Orders"}}}}})
+		assert.Error(t, err, "Preload should validate nested relations against the related model")
+
+		_, err = repo.FindAll(ctx, crud.Specification[Customer]{Preloads: []crud.Preload{{Relation: "Orders", Filter: crud.Eq("name", "Alice")}}})
+		assert.Error(t, err, "Preload should validate filters against the related model")
+	})
+}
@@ -0,0 +1,82 @@
+package gocrud_test
+
+import (
+	"context"
+	"testing"
+
+	crud "github.com/itsLeonB/go-crud"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestModelSummary is a projection of TestModel
+type TestModelSummary struct {
+	Name string
+	Age  int
+}
+
+func TestFindAllAs(t *testing.T) {
+	db := setupSoftDeleteTestDB(t)
+	seedFilterData(t, db)
+	repo := crud.NewRepository[TestModel](db)
+	ctx := context.Background()
+
+	summaries, err := crud.FindAllAs[TestModel, TestModelSummary](ctx, repo, crud.Specification[TestModel]{
+		Filter:  crud.Lte("age", 30),
+		OrderBy: []crud.Sort{{Field: "age"}},
+	})
+	assert.NoError(t, err, "FindAllAs should not return error")
+	assert.Equal(t, []TestModelSummary{{"Alice", 25}, {"Bob", 30}}, summaries, "FindAllAs should map columns into the DTO")
+
+	t.Run("respects deleted filter", func(t *testing.T) {
+		softRepo := crud.NewRepository[NullTimeModel](db)
+		models, err := softRepo.InsertMany(ctx, []NullTimeModel{{Name: "Alice"}, {Name: "Bob"}})
+		assert.NoError(t, err, "Failed to insert test data")
+		err = softRepo.SoftDelete(ctx, models[0])
+		assert.NoError(t, err, "SoftDelete should not return error")
+
+		type nameOnly struct{ Name string }
+		names, err := crud.FindAllAs[NullTimeModel, nameOnly](ctx, softRepo, crud.Specification[NullTimeModel]{})
+		assert.NoError(t, err, "FindAllAs should not return error")
+		assert.Equal(t, []nameOnly{{"Bob"}}, names, "FindAllAs should exclude soft-deleted records")
+	})
+}
+
+func TestPluck(t *testing.T) {
+	db := setupScopesTestDB(t)
+	seedFilterData(t, db)
+	repo := crud.NewRepository[TestModel](db)
+	ctx := context.Background()
+
+	names, err := crud.Pluck[string](ctx, repo, crud.Specification[TestModel]{Filter: crud.Gte("age", 35), OrderBy: []crud.Sort{{Field: "age"}}}, "Name")
+	assert.NoError(t, err, "Pluck should not return error")
+	assert.Equal(t, []string{"Charlie", "alfred", "Dave"}, names, "Pluck should return a single column")
+
+	ages, err := crud.Pluck[int](ctx, repo, crud.Specification[TestModel]{Model: TestModel{Name: "Bob"}, Select: []string{"name", "email"}}, "age")
+	assert.NoError(t, err, "Pluck should not return error")
+	assert.Equal(t, []int{30}, ages, "Pluck should ignore Select")
+
+	_, err = crud.Pluck[string](ctx, repo, crud.Specification[TestModel]{}, "name, email")
+	assert.Error(t, err, "Pluck should reject invalid field names")
+}
+
+func TestRepository_FindAll_SelectOmit(t *testing.T) {
+	db := setupScopesTestDB(t)
+	seedFilterData(t, db)
+	repo := crud.NewRepository[TestModel](db)
+	ctx := context.Background()
+
+	selected, err := repo.FindOne(ctx, crud.Specification[TestModel]{Model: TestModel{Name: "Bob"}, Select: []string{"ID", "name"}})
+	assert.NoError(t, err, "FindOne should not return error")
+	assert.NotZero(t, selected.ID, "Select should load listed columns")
+	assert.Equal(t, "Bob", selected.Name, "Select should load listed columns")
+	assert.Zero(t, selected.Age, "Select should leave other columns unloaded")
+
+	omitted, err := repo.FindAll(ctx, crud.Specification[TestModel]{Filter: crud.Eq("name", "Bob"), Omit: []string{"email"}})
+	assert.NoError(t, err, "FindAll should not return error")
+	assert.Len(t, omitted, 1, "FindAll should return matching records")
+	assert.Empty(t, omitted[0].Email, "Omit should leave omitted columns unloaded")
+	assert.Equal(t, 30, omitted[0].Age, "Omit should load other columns")
+
+	_, err = repo.FindAll(ctx, crud.Specification[TestModel]{Select: []string{"name; DROP TABLE test_models"}})
+	assert.Error(t, err, "Select should reject invalid field names")
+}
@@ -0,0 +1,88 @@
+package gocrud_test
+
+import (
+	"context"
+	"testing"
+
+	crud "github.com/itsLeonB/go-crud"
+	"github.com/stretchr/testify/assert"
+)
+
+type Tag struct {
+	ID   uint `gorm:"primaryKey"`
+	Name string
+}
+
+// TaggedCustomer shares the customers table and adds a many-to-many relation
+type TaggedCustomer struct {
+	ID     uint `gorm:"primaryKey"`
+	Name   string
+	Orders []Order `gorm:"foreignKey:CustomerID"`
+	Tags   []Tag   `gorm:"many2many:customer_tags;joinForeignKey:CustomerID"`
+}
+
+func (TaggedCustomer) TableName() string { return "customers" }
+
+func customerNames(customers []TaggedCustomer) []string {
+	names := make([]string, len(customers))
+	for i, customer := range customers {
+		names[i] = customer.Name
+	}
+	return names
+}
+
+func TestRelationFilters(t *testing.T) {
+	db := setupPreloadTestDB(t)
+	err := db.AutoMigrate(&TaggedCustomer{}, &Tag{})
+	assert.NoError(t, err, "Failed to migrate test models")
+
+	carol := TaggedCustomer{Name: "Carol", Tags: []Tag{{Name: "vip"}}}
+	err = db.Create(&carol).Error
+	assert.NoError(t, err, "Failed to create test data")
+	var alice TaggedCustomer
+	err = db.Where("name = ?", "Alice").First(&alice).Error
+	assert.NoError(t, err, "Failed to load test data")
+	err = db.Model(&alice).Association("Tags").Append(&Tag{Name: "new"})
+	assert.NoError(t, err, "Failed to tag customer")
+
+	repo := crud.NewRepository[TaggedCustomer](db)
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		filter    crud.Filter
+		wantNames []string
+	}{
+		{"has", crud.Has("Orders"), []string{"Alice", "Bob"}},
+		{"doesnt have", crud.DoesntHave("Orders"), []string{"Carol"}},
+		{"where has", crud.WhereHas("Orders", crud.Gte("total", 30)), []string{"Alice"}},
+		{"where has ignores soft-deleted records", crud.WhereHas("Orders", crud.Eq("total", 40)), []string{}},
+		{"where doesnt have", crud.WhereDoesntHave("Orders", crud.Eq("status", "cancelled")), []string{"Carol"}},
+		{"nested", crud.WhereHas("Orders", crud.WhereHas("Items", crud.Eq("sku", "B1"))), []string{"Alice"}},
+		{"many to many", crud.WhereHas("Tags", crud.Eq("name", "vip")), []string{"Carol"}},
+		{"combined with other filters", crud.And(crud.Has("Tags"), crud.Has("Orders")), []string{"Alice"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := repo.FindAll(ctx, crud.Specification[TaggedCustomer]{Filter: tt.filter, OrderBy: []crud.Sort{{Field: "name"}}})
+			assert.NoError(t, err, "FindAll should not return error")
+			assert.Equal(t, tt.wantNames, customerNames(results), "Relation filter should return expected records")
+		})
+	}
+
+	t.Run("belongs to", func(t *testing.T) {
+		orderRepo := crud.NewRepository[Order](db)
+		count, err := orderRepo.Count(ctx, crud.Specification[Order]{Filter: crud.WhereHas("Customer", crud.Eq("name", "Bob"))})
+		assert.NoError(t, err, "Count should not return error")
+		assert.Equal(t, int64(2), count, "Relation filter should follow belongs-to relations")
+	})
+
+	t.Run("invalid relation", func(t *testing.T) {
+		_, err := repo.FindAll(ctx, crud.Specification[TaggedCustomer]{Filter: crud.Has("Invoices")})
+		assert.Error(t, err, "Relation filter should reject unknown relations")
+
+		_, err = repo.FindAll(ctx, crud.Specification[TaggedCustomer]{Filter: crud.WhereHas("Orders", crud.Eq("sku", "A1"))})
+		assert.Error(t, err, "Relation filter should validate the inner filter against the related model")
+	})
+}
@@ -2,6 +2,8 @@ package gocrud_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -100,6 +102,20 @@ func TestRepository_FindAll(t *testing.T) {
 		assert.Len(t, results, 1, "FindAll should return 1 record for Alice")
 		assert.Equal(t, "Alice", results[0].Name, "Found record should be Alice")
 	})
+
+	t.Run("find by zero value field", func(t *testing.T) {
+		_, err := repo.Insert(ctx, TestModel{Name: "Newborn", Email: "newborn@example.com", Age: 0})
+		assert.NoError(t, err, "Failed to insert test data")
+
+		spec := crud.Specification[TestModel]{
+			Model:  TestModel{Age: 0},
+			Fields: []string{"Age"},
+		}
+		results, err := repo.FindAll(ctx, spec)
+		assert.NoError(t, err, "FindAll should not return error")
+		assert.Len(t, results, 1, "FindAll should match zero values listed in Fields")
+		assert.Equal(t, "Newborn", results[0].Name, "Found record should be Newborn")
+	})
 }
 
 func TestRepository_FindFirst(t *testing.T) {
@@ -131,6 +147,86 @@ func TestRepository_FindFirst(t *testing.T) {
 	})
 }
 
+func TestRepository_FindAll_OrderLimitOffset(t *testing.T) {
+	db := setupTestDB(t)
+	repo := crud.NewRepository[TestModel](db)
+	ctx := context.Background()
+
+	now := time.Now()
+	testModels := []TestModel{
+		{Name: "Bob", Email: "bob@example.com", Age: 30, CreatedAt: now.Add(-2 * time.Hour)},
+		{Name: "Alice", Email: "alice@example.com", Age: 35, CreatedAt: now.Add(-1 * time.Hour)},
+		{Name: "Charlie", Email: "charlie@example.com", Age: 25, CreatedAt: now},
+	}
+	for _, model := range testModels {
+		_, err := repo.Insert(ctx, model)
+		assert.NoError(t, err, "Failed to insert test data")
+	}
+
+	tests := []struct {
+		name      string
+		spec      crud.Specification[TestModel]
+		wantNames []string
+	}{
+		{"default order", crud.Specification[TestModel]{}, []string{"Charlie", "Alice", "Bob"}},
+		{"order by column", crud.Specification[TestModel]{OrderBy: []crud.Sort{{Field: "name"}}}, []string{"Alice", "Bob", "Charlie"}},
+		{"order by struct field", crud.Specification[TestModel]{OrderBy: []crud.Sort{{Field: "Age", Desc: true}}}, []string{"Alice", "Bob", "Charlie"}},
+		{"limit", crud.Specification[TestModel]{OrderBy: []crud.Sort{{Field: "age"}}, Limit: 2}, []string{"Charlie", "Bob"}},
+		{"limit and offset", crud.Specification[TestModel]{OrderBy: []crud.Sort{{Field: "age"}}, Limit: 2, Offset: 1}, []string{"Bob", "Alice"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := repo.FindAll(ctx, tt.spec)
+			assert.NoError(t, err, "FindAll should not return error")
+			assert.Equal(t, tt.wantNames, testModelNames(results), "FindAll should return records in expected order")
+		})
+	}
+
+	t.Run("find first uses order", func(t *testing.T) {
+		result, err := repo.FindFirst(ctx, crud.Specification[TestModel]{OrderBy: []crud.Sort{{Field: "age"}}})
+		assert.NoError(t, err, "FindFirst should not return error")
+		assert.Equal(t, "Charlie", result.Name, "FindFirst should return the youngest record")
+	})
+
+	t.Run("invalid order field", func(t *testing.T) {
+		_, err := repo.FindAll(ctx, crud.Specification[TestModel]{OrderBy: []crud.Sort{{Field: "name desc; --"}}})
+		assert.Error(t, err, "FindAll should reject invalid order fields")
+	})
+}
+
+func TestRepository_FindOne(t *testing.T) {
+	db := setupTestDB(t)
+	repo := crud.NewRepository[TestModel](db)
+	ctx := context.Background()
+
+	_, err := repo.Insert(ctx, TestModel{Name: "Alice", Email: "alice@example.com", Age: 25})
+	assert.NoError(t, err, "Failed to insert test data")
+
+	t.Run("find existing record", func(t *testing.T) {
+		result, err := repo.FindOne(ctx, crud.Specification[TestModel]{Model: TestModel{Name: "Alice"}})
+		assert.NoError(t, err, "FindOne should not return error")
+		assert.Equal(t, "Alice", result.Name, "Found record should have correct name")
+	})
+
+	t.Run("find non-existent record", func(t *testing.T) {
+		result, err := repo.FindOne(ctx, crud.Specification[TestModel]{Model: TestModel{Name: "NonExistent"}})
+		assert.Error(t, err, "FindOne should return error for non-existent record")
+		assert.True(t, errors.Is(err, crud.ErrNotFound), "FindOne error should match ErrNotFound")
+		assert.Zero(t, result.ID, "FindOne should return zero value when not found")
+	})
+}
+
+func TestRepository_FindFirst_Strict(t *testing.T) {
+	db := setupTestDB(t)
+	repo := crud.NewRepository[TestModel](db, crud.WithStrictFindFirst())
+	ctx := context.Background()
+
+	_, err := repo.FindFirst(ctx, crud.Specification[TestModel]{Model: TestModel{Name: "NonExistent"}})
+	assert.Error(t, err, "Strict FindFirst should return error for non-existent record")
+	assert.True(t, errors.Is(err, crud.ErrNotFound), "Strict FindFirst error should match ErrNotFound")
+}
+
 func TestRepository_Update(t *testing.T) {
 	db := setupTestDB(t)
 	repo := crud.NewRepository[TestModel](db)
@@ -248,3 +344,48 @@ func TestSpecification(t *testing.T) {
 	assert.Contains(t, spec.PreloadRelations, "Posts", "Specification should contain Posts relation")
 	assert.True(t, spec.ForUpdate, "Specification.ForUpdate should be true")
 }
+
+func TestRepository_FindPage(t *testing.T) {
+	db := setupTestDB(t)
+	repo := crud.NewRepository[TestModel](db, crud.WithMaxPageSize(3))
+	ctx := context.Background()
+
+	for i := range 5 {
+		_, err := repo.Insert(ctx, TestModel{Name: "User", Email: fmt.Sprintf("user%d@example.com", i), Age: 20 + i%2})
+		assert.NoError(t, err, "Failed to insert test data")
+	}
+
+	tests := []struct {
+		name           string
+		spec           crud.Specification[TestModel]
+		req            crud.PageRequest
+		wantItems      int
+		wantTotal      int64
+		wantPage       int
+		wantLimit      int
+		wantTotalPages int
+		wantHasNext    bool
+	}{
+		{"first page", crud.Specification[TestModel]{}, crud.PageRequest{Page: 1, Limit: 2}, 2, 5, 1, 2, 3, true},
+		{"last page", crud.Specification[TestModel]{}, crud.PageRequest{Page: 3, Limit: 2}, 1, 5, 3, 2, 3, false},
+		{"page out of range", crud.Specification[TestModel]{}, crud.PageRequest{Page: 4, Limit: 2}, 0, 5, 4, 2, 3, false},
+		{"limit capped to max page size", crud.Specification[TestModel]{}, crud.PageRequest{Page: 1, Limit: 50}, 3, 5, 1, 3, 2, true},
+		{"zero page defaults to first", crud.Specification[TestModel]{}, crud.PageRequest{Limit: 2}, 2, 5, 1, 2, 3, true},
+		{"count uses spec filters", crud.Specification[TestModel]{Model: TestModel{Age: 21}}, crud.PageRequest{Page: 1, Limit: 3}, 2, 2, 1, 3, 1, false},
+		{"no matches", crud.Specification[TestModel]{Model: TestModel{Name: "Nobody"}}, crud.PageRequest{Page: 1, Limit: 3}, 0, 0, 1, 3, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := repo.FindPage(ctx, tt.spec, tt.req)
+			assert.NoError(t, err, "FindPage should not return error")
+			assert.Len(t, page.Items, tt.wantItems, "FindPage should return expected number of items")
+			assert.NotNil(t, page.Items, "FindPage should return an empty slice rather than nil")
+			assert.Equal(t, tt.wantTotal, page.Total, "FindPage should return expected total")
+			assert.Equal(t, tt.wantPage, page.Page, "FindPage should return expected page")
+			assert.Equal(t, tt.wantLimit, page.Limit, "FindPage should return applied limit")
+			assert.Equal(t, tt.wantTotalPages, page.TotalPages, "FindPage should return expected total pages")
+			assert.Equal(t, tt.wantHasNext, page.HasNext, "FindPage should return expected HasNext")
+		})
+	}
+}
@@ -323,3 +323,86 @@ func TestDeletedFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestWhereBySpec_Fields(t *testing.T) {
+	db := setupScopesTestDB(t)
+
+	testModels := []TestModel{
+		{Name: "Alice", Email: "alice@example.com", Age: 0},
+		{Name: "Bob", Email: "bob@example.com", Age: 30},
+		{Name: "Charlie", Email: "charlie@example.com", Age: 0},
+	}
+	err := db.Create(&testModels).Error
+	assert.NoError(t, err, "Failed to create test data")
+
+	tests := []struct {
+		name      string
+		spec      TestModel
+		fields    []string
+		wantCount int
+		wantErr   bool
+	}{
+		{"zero value ignored without fields", TestModel{Age: 0}, nil, 3, false},
+		{"zero value matched by column name", TestModel{Age: 0}, []string{"age"}, 2, false},
+		{"zero value matched by field name", TestModel{Age: 0}, []string{"Age"}, 2, false},
+		{"non-zero fields still applied", TestModel{Name: "Alice", Age: 0}, []string{"age"}, 1, false},
+		{"unknown field", TestModel{}, []string{"missing"}, 0, true},
+		{"invalid field", TestModel{}, []string{"age; DROP TABLE test_models"}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var results []TestModel
+			err := db.Scopes(crud.WhereBySpec(tt.spec, tt.fields...)).Find(&results).Error
+			if tt.wantErr {
+				assert.Error(t, err, "WhereBySpec should return error for invalid fields")
+				return
+			}
+			assert.NoError(t, err, "WhereBySpec should not return error")
+			assert.Len(t, results, tt.wantCount, "WhereBySpec should return expected number of records")
+		})
+	}
+
+	t.Run("pointer spec", func(t *testing.T) {
+		var results []TestModel
+		err := db.Scopes(crud.WhereBySpec(&TestModel{Age: 0}, "age")).Find(&results).Error
+		assert.NoError(t, err, "WhereBySpec should not return error")
+		assert.Len(t, results, 2, "WhereBySpec should match zero fields of pointer specs")
+	})
+}
+
+func TestSortBy(t *testing.T) {
+	db := setupScopesTestDB(t)
+	seedFilterData(t, db)
+
+	tests := []struct {
+		name      string
+		sorts     []crud.Sort
+		wantFirst string
+		wantLast  string
+		wantErr   bool
+	}{
+		{"single ascending", []crud.Sort{{Field: "age"}}, "Alice", "Dave", false},
+		{"single descending", []crud.Sort{{Field: "age", Desc: true}}, "Dave", "Alice", false},
+		{"nulls first", []crud.Sort{{Field: "email", Nulls: crud.NullsFirst}}, "Dave", "charlie@example.com", false},
+		{"nulls last", []crud.Sort{{Field: "email", Nulls: crud.NullsLast}}, "alfred@example.com", "Dave", false},
+		{"invalid field name", []crud.Sort{{Field: "age; DROP TABLE test_models"}}, "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var results []TestModel
+			err := db.Scopes(crud.SortBy(tt.sorts...)).Find(&results).Error
+			if tt.wantErr {
+				assert.Error(t, err, "SortBy should return error for invalid field")
+				return
+			}
+
+			assert.NoError(t, err, "SortBy should not return error")
+			assert.Len(t, results, 5, "SortBy should return all records")
+			first, last := results[0], results[len(results)-1]
+			assert.Contains(t, []string{first.Name, first.Email}, tt.wantFirst, "SortBy should return correct first result")
+			assert.Contains(t, []string{last.Name, last.Email}, tt.wantLast, "SortBy should return correct last result")
+		})
+	}
+}
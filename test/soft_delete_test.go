@@ -0,0 +1,159 @@
+package gocrud_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	crud "github.com/itsLeonB/go-crud"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// NullTimeModel follows BaseEntity's soft delete convention
+type NullTimeModel struct {
+	ID        uint   `gorm:"primaryKey"`
+	Name      string `gorm:"not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt sql.NullTime
+}
+
+func setupSoftDeleteTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	assert.NoError(t, err, "Failed to connect to test database")
+
+	err = db.AutoMigrate(&NullTimeModel{}, &SoftDeleteModel{}, &TestModel{})
+	assert.NoError(t, err, "Failed to migrate test models")
+
+	return db
+}
+
+func TestRepository_SoftDelete(t *testing.T) {
+	db := setupSoftDeleteTestDB(t)
+	repo := crud.NewRepository[NullTimeModel](db)
+	ctx := context.Background()
+
+	models, err := repo.InsertMany(ctx, []NullTimeModel{{Name: "Alice"}, {Name: "Bob"}, {Name: "Charlie"}})
+	assert.NoError(t, err, "Failed to insert test data")
+
+	t.Run("soft delete one", func(t *testing.T) {
+		err := repo.SoftDelete(ctx, models[0])
+		assert.NoError(t, err, "SoftDelete should not return error")
+
+		results, err := repo.FindAll(ctx, crud.Specification[NullTimeModel]{})
+		assert.NoError(t, err, "FindAll should not return error")
+		assert.Len(t, results, 2, "FindAll should exclude soft-deleted records by default")
+
+		deleted, err := repo.FindFirst(ctx, crud.Specification[NullTimeModel]{
+			Model:         NullTimeModel{ID: models[0].ID},
+			DeletedFilter: crud.OnlyDeleted,
+		})
+		assert.NoError(t, err, "FindFirst should not return error")
+		assert.True(t, deleted.DeletedAt.Valid, "SoftDelete should set DeletedAt")
+	})
+
+	t.Run("soft delete many", func(t *testing.T) {
+		err := repo.SoftDeleteMany(ctx, models[1:])
+		assert.NoError(t, err, "SoftDeleteMany should not return error")
+
+		results, err := repo.FindAll(ctx, crud.Specification[NullTimeModel]{})
+		assert.NoError(t, err, "FindAll should not return error")
+		assert.Empty(t, results, "FindAll should exclude all soft-deleted records")
+
+		all, err := repo.FindAll(ctx, crud.Specification[NullTimeModel]{DeletedFilter: crud.IncludeDeleted})
+		assert.NoError(t, err, "FindAll should not return error")
+		assert.Len(t, all, 3, "IncludeDeleted should return soft-deleted records")
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		err := repo.Restore(ctx, models[0])
+		assert.NoError(t, err, "Restore should not return error")
+
+		err = repo.RestoreMany(ctx, models[1:])
+		assert.NoError(t, err, "RestoreMany should not return error")
+
+		results, err := repo.FindAll(ctx, crud.Specification[NullTimeModel]{})
+		assert.NoError(t, err, "FindAll should not return error")
+		assert.Len(t, results, 3, "Restored records should be visible again")
+		for _, result := range results {
+			assert.False(t, result.DeletedAt.Valid, "Restore should clear DeletedAt")
+		}
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		assert.Error(t, repo.SoftDelete(ctx, NullTimeModel{}), "SoftDelete should reject zero value")
+		assert.Error(t, repo.SoftDeleteMany(ctx, nil), "SoftDeleteMany should reject empty slice")
+		assert.Error(t, repo.RestoreMany(ctx, []NullTimeModel{{Name: "No ID"}}), "RestoreMany should reject models without primary key")
+	})
+
+	t.Run("model without deleted column", func(t *testing.T) {
+		testRepo := crud.NewRepository[TestModel](db)
+		inserted, err := testRepo.Insert(ctx, TestModel{Name: "Alice", Email: "alice@example.com"})
+		assert.NoError(t, err, "Failed to insert test data")
+		assert.Error(t, testRepo.SoftDelete(ctx, inserted), "SoftDelete should require a deleted column")
+
+		results, err := testRepo.FindAll(ctx, crud.Specification[TestModel]{DeletedFilter: crud.IncludeDeleted})
+		assert.NoError(t, err, "IncludeDeleted should be accepted without a deleted column")
+		assert.Len(t, results, 1, "IncludeDeleted should return every record")
+
+		_, err = testRepo.FindAll(ctx, crud.Specification[TestModel]{DeletedFilter: crud.OnlyDeleted})
+		assert.Error(t, err, "OnlyDeleted should require a deleted column")
+	})
+}
+
+func TestRepository_SoftDelete_GormDeletedAt(t *testing.T) {
+	db := setupSoftDeleteTestDB(t)
+	repo := crud.NewRepository[SoftDeleteModel](db)
+	ctx := context.Background()
+
+	models, err := repo.InsertMany(ctx, []SoftDeleteModel{{Name: "Alice"}, {Name: "Bob"}})
+	assert.NoError(t, err, "Failed to insert test data")
+
+	err = repo.SoftDelete(ctx, models[0])
+	assert.NoError(t, err, "SoftDelete should not return error")
+
+	var count int64
+	err = db.Model(&SoftDeleteModel{}).Count(&count).Error
+	assert.NoError(t, err, "Failed to count records")
+	assert.Equal(t, int64(1), count, "GORM should see the record as soft deleted")
+
+	deleted, err := repo.FindAll(ctx, crud.Specification[SoftDeleteModel]{DeletedFilter: crud.OnlyDeleted})
+	assert.NoError(t, err, "FindAll should not return error")
+	assert.Len(t, deleted, 1, "OnlyDeleted should work without Unscoped")
+
+	all, err := repo.FindAll(ctx, crud.Specification[SoftDeleteModel]{DeletedFilter: crud.IncludeDeleted})
+	assert.NoError(t, err, "FindAll should not return error")
+	assert.Len(t, all, 2, "IncludeDeleted should work without Unscoped")
+
+	err = repo.Restore(ctx, models[0])
+	assert.NoError(t, err, "Restore should not return error")
+
+	active, err := repo.FindAll(ctx, crud.Specification[SoftDeleteModel]{})
+	assert.NoError(t, err, "FindAll should not return error")
+	assert.Len(t, active, 2, "Restored record should be visible again")
+}
+
+func TestRepository_DefaultDeletedFilter(t *testing.T) {
+	db := setupSoftDeleteTestDB(t)
+	repo := crud.NewRepository[NullTimeModel](db, crud.WithDefaultDeletedFilter(crud.IncludeDeleted))
+	ctx := context.Background()
+
+	models, err := repo.InsertMany(ctx, []NullTimeModel{{Name: "Alice"}, {Name: "Bob"}})
+	assert.NoError(t, err, "Failed to insert test data")
+	err = repo.SoftDelete(ctx, models[0])
+	assert.NoError(t, err, "SoftDelete should not return error")
+
+	results, err := repo.FindAll(ctx, crud.Specification[NullTimeModel]{})
+	assert.NoError(t, err, "FindAll should not return error")
+	assert.Len(t, results, 2, "Configured default filter should include deleted records")
+
+	results, err = repo.FindAll(ctx, crud.Specification[NullTimeModel]{DeletedFilter: crud.ExcludeDeleted})
+	assert.NoError(t, err, "FindAll should not return error")
+	assert.Len(t, results, 1, "Specification filter should override the default")
+}
@@ -2,10 +2,14 @@ package gocrud_test
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	crud "github.com/itsLeonB/go-crud"
+	"github.com/itsLeonB/go-crud/lib"
+	"github.com/rotisserie/eris"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -24,6 +28,19 @@ func setupTransactorTestDB(t *testing.T) *gorm.DB {
 	return db
 }
 
+// setupTransactorFileDB opens a database file that, unlike :memory:, is shared by all pooled connections.
+func setupTransactorFileDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(t.TempDir()+"/test.db?_journal_mode=WAL&_busy_timeout=5000"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	assert.NoError(t, err, "Failed to connect to test database")
+
+	err = db.AutoMigrate(&TestModel{})
+	assert.NoError(t, err, "Failed to migrate test models")
+
+	return db
+}
+
 func TestNewTransactor(t *testing.T) {
 	db := setupTransactorTestDB(t)
 	transactor := crud.NewTransactor(db)
@@ -218,6 +235,103 @@ func TestTransactor_WithinTransaction_Nested(t *testing.T) {
 	assert.NotZero(t, innerResult.ID, "WithinTransaction inner record should be committed")
 }
 
+func TestTransactor_WithinTransaction_Savepoint(t *testing.T) {
+	ctx := context.Background()
+	errInner := errors.New("inner failure")
+
+	insert := func(repo crud.Repository[TestModel], ctx context.Context, name string) error {
+		_, err := repo.Insert(ctx, TestModel{Name: name, Email: name + "@example.com"})
+		return err
+	}
+	names := func(t *testing.T, repo crud.Repository[TestModel]) []string {
+		results, err := repo.FindAll(ctx, crud.Specification[TestModel]{OrderBy: []crud.Sort{{Field: "id"}}})
+		assert.NoError(t, err, "FindAll should not return error")
+		return testModelNames(results)
+	}
+
+	t.Run("inner failure is rolled back to the savepoint", func(t *testing.T) {
+		db := setupTransactorTestDB(t)
+		transactor := crud.NewTransactor(db)
+		repo := crud.NewRepository[TestModel](db)
+
+		err := transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+			if err := insert(repo, txCtx, "outer"); err != nil {
+				return err
+			}
+
+			err := transactor.WithinTransaction(txCtx, func(innerCtx context.Context) error {
+				if err := insert(repo, innerCtx, "inner"); err != nil {
+					return err
+				}
+				return errInner
+			})
+			assert.ErrorIs(t, err, errInner, "Nested WithinTransaction should return the inner error")
+
+			// The outer transaction is still usable after the inner one failed
+			return insert(repo, txCtx, "after")
+		})
+
+		assert.NoError(t, err, "WithinTransaction should not return error")
+		assert.Equal(t, []string{"outer", "after"}, names(t, repo), "Only the failed inner writes should be rolled back")
+	})
+
+	t.Run("successful savepoints are released", func(t *testing.T) {
+		db := setupTransactorTestDB(t)
+		transactor := crud.NewTransactor(db)
+		repo := crud.NewRepository[TestModel](db)
+
+		err := transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+			return transactor.WithinTransaction(txCtx, func(innerCtx context.Context) error {
+				if err := insert(repo, innerCtx, "inner"); err != nil {
+					return err
+				}
+				return transactor.WithinTransaction(innerCtx, func(deepCtx context.Context) error {
+					return insert(repo, deepCtx, "deep")
+				})
+			})
+		})
+
+		assert.NoError(t, err, "WithinTransaction should not return error")
+		assert.Equal(t, []string{"inner", "deep"}, names(t, repo), "Nested writes should be committed with the outer transaction")
+	})
+
+	t.Run("outer failure rolls back released savepoints", func(t *testing.T) {
+		db := setupTransactorTestDB(t)
+		transactor := crud.NewTransactor(db)
+		repo := crud.NewRepository[TestModel](db)
+
+		err := transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+			err := transactor.WithinTransaction(txCtx, func(innerCtx context.Context) error {
+				return insert(repo, innerCtx, "inner")
+			})
+			assert.NoError(t, err, "Nested WithinTransaction should not return error")
+			return errInner
+		})
+
+		assert.ErrorIs(t, err, errInner, "WithinTransaction should return the outer error")
+		assert.Empty(t, names(t, repo), "Outer rollback should undo nested writes")
+	})
+
+	t.Run("joined nested transactions", func(t *testing.T) {
+		db := setupTransactorTestDB(t)
+		transactor := crud.NewTransactor(db, crud.WithJoinedNestedTransactions())
+		repo := crud.NewRepository[TestModel](db)
+
+		err := transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+			_ = transactor.WithinTransaction(txCtx, func(innerCtx context.Context) error {
+				if err := insert(repo, innerCtx, "inner"); err != nil {
+					return err
+				}
+				return errInner
+			})
+			return nil
+		})
+
+		assert.NoError(t, err, "WithinTransaction should not return error")
+		assert.Equal(t, []string{"inner"}, names(t, repo), "Joined nested calls should keep their writes when the error is swallowed")
+	})
+}
+
 func TestGetTxFromContext(t *testing.T) {
 	db := setupTransactorTestDB(t)
 	transactor := crud.NewTransactor(db)
@@ -238,3 +352,379 @@ func TestGetTxFromContext(t *testing.T) {
 		assert.Nil(t, tx, "GetTxFromContext should return nil when no transaction")
 	})
 }
+
+func TestTransactor_WithinTransactionOpts(t *testing.T) {
+	ctx := context.Background()
+	errOuter := errors.New("outer failure")
+
+	t.Run("requires new commits independently", func(t *testing.T) {
+		// Independent transactions need separate connections to the same database
+		db := setupTransactorFileDB(t)
+		transactor := crud.NewTransactor(db)
+		repo := crud.NewRepository[TestModel](db)
+
+		err := transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+			err := transactor.WithinTransactionOpts(txCtx, crud.TxOptions{Propagation: crud.PropagationRequiresNew}, func(auditCtx context.Context) error {
+				outerTx, _ := crud.GetTxFromContext(txCtx)
+				auditTx, _ := crud.GetTxFromContext(auditCtx)
+				assert.NotSame(t, outerTx, auditTx, "RequiresNew should begin a separate transaction")
+
+				_, err := repo.Insert(auditCtx, TestModel{Name: "audit", Email: "audit@example.com"})
+				return err
+			})
+			assert.NoError(t, err, "RequiresNew should not return error")
+
+			if _, err = repo.Insert(txCtx, TestModel{Name: "work", Email: "work@example.com"}); err != nil {
+				return err
+			}
+			return errOuter
+		})
+		assert.ErrorIs(t, err, errOuter, "WithinTransaction should return the outer error")
+
+		results, err := repo.FindAll(ctx, crud.Specification[TestModel]{})
+		assert.NoError(t, err, "FindAll should not return error")
+		assert.Equal(t, []string{"audit"}, testModelNames(results), "RequiresNew writes should survive the outer rollback")
+	})
+
+	t.Run("mandatory", func(t *testing.T) {
+		transactor := crud.NewTransactor(setupTransactorTestDB(t))
+		opts := crud.TxOptions{Propagation: crud.PropagationMandatory}
+		called := false
+
+		err := transactor.WithinTransactionOpts(ctx, opts, func(context.Context) error {
+			called = true
+			return nil
+		})
+		assert.ErrorIs(t, err, crud.ErrNoTransaction, "Mandatory should fail without a transaction")
+		assert.False(t, called, "Mandatory should not run the function without a transaction")
+
+		err = transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+			return transactor.WithinTransactionOpts(txCtx, opts, func(innerCtx context.Context) error {
+				tx, err := crud.GetTxFromContext(innerCtx)
+				assert.NotNil(t, tx, "Mandatory should run within the existing transaction")
+				return err
+			})
+		})
+		assert.NoError(t, err, "Mandatory should run within a transaction")
+	})
+
+	t.Run("never", func(t *testing.T) {
+		transactor := crud.NewTransactor(setupTransactorTestDB(t))
+		opts := crud.TxOptions{Propagation: crud.PropagationNever}
+
+		err := transactor.WithinTransactionOpts(ctx, opts, func(innerCtx context.Context) error {
+			tx, err := crud.GetTxFromContext(innerCtx)
+			assert.Nil(t, tx, "Never should run without a transaction")
+			return err
+		})
+		assert.NoError(t, err, "Never should run outside a transaction")
+
+		err = transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+			return transactor.WithinTransactionOpts(txCtx, opts, func(context.Context) error { return nil })
+		})
+		assert.ErrorIs(t, err, crud.ErrTransactionExists, "Never should fail within a transaction")
+	})
+
+	t.Run("supports", func(t *testing.T) {
+		transactor := crud.NewTransactor(setupTransactorTestDB(t))
+		opts := crud.TxOptions{Propagation: crud.PropagationSupports}
+
+		err := transactor.WithinTransactionOpts(ctx, opts, func(innerCtx context.Context) error {
+			tx, err := crud.GetTxFromContext(innerCtx)
+			assert.Nil(t, tx, "Supports should not begin a transaction")
+			return err
+		})
+		assert.NoError(t, err, "Supports should run without a transaction")
+	})
+
+	t.Run("unknown propagation", func(t *testing.T) {
+		transactor := crud.NewTransactor(setupTransactorTestDB(t))
+		err := transactor.WithinTransactionOpts(ctx, crud.TxOptions{Propagation: crud.Propagation(42)}, func(context.Context) error { return nil })
+		assert.Error(t, err, "WithinTransactionOpts should reject unknown propagation modes")
+	})
+}
+
+func TestTransactor_BeginWith(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("isolation and read only", func(t *testing.T) {
+		transactor := crud.NewTransactor(setupTransactorTestDB(t))
+
+		txCtx, err := transactor.BeginWith(ctx, crud.TxOptions{Isolation: sql.LevelSerializable, ReadOnly: true})
+		assert.NoError(t, err, "BeginWith should not return error")
+
+		tx, err := crud.GetTxFromContext(txCtx)
+		assert.NoError(t, err, "GetTxFromContext should not return error")
+		assert.NotNil(t, tx, "BeginWith should store the transaction in the context")
+		_, hasDeadline := txCtx.Deadline()
+		assert.False(t, hasDeadline, "BeginWith should not set a deadline without a timeout")
+
+		assert.NoError(t, transactor.Commit(txCtx), "Commit should not return error")
+	})
+
+	t.Run("timeout sets a deadline", func(t *testing.T) {
+		transactor := crud.NewTransactor(setupTransactorTestDB(t))
+
+		txCtx, err := transactor.BeginWith(ctx, crud.TxOptions{Timeout: time.Minute})
+		assert.NoError(t, err, "BeginWith should not return error")
+
+		deadline, hasDeadline := txCtx.Deadline()
+		assert.True(t, hasDeadline, "BeginWith should set a deadline for a timeout")
+		assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second, "Deadline should match the timeout")
+
+		assert.NoError(t, transactor.Commit(txCtx), "Commit should not return error")
+		assert.ErrorIs(t, txCtx.Err(), context.Canceled, "Commit should release the timeout")
+	})
+
+	t.Run("exceeded timeout rolls back", func(t *testing.T) {
+		db := setupTransactorFileDB(t)
+		transactor := crud.NewTransactor(db)
+		repo := crud.NewRepository[TestModel](db)
+
+		opts := crud.TxOptions{Timeout: 50 * time.Millisecond}
+		err := transactor.WithinTransactionOpts(ctx, opts, func(txCtx context.Context) error {
+			if _, err := repo.Insert(txCtx, TestModel{Name: "slow", Email: "slow@example.com"}); err != nil {
+				return err
+			}
+			<-txCtx.Done()
+			return nil
+		})
+		assert.ErrorIs(t, err, context.DeadlineExceeded, "WithinTransactionOpts should report the timeout")
+
+		count, err := repo.Count(ctx, crud.Specification[TestModel]{})
+		assert.NoError(t, err, "Count should not return error")
+		assert.Zero(t, count, "Writes of a timed out transaction should be rolled back")
+	})
+}
+
+func TestTransactor_WithinTransactionOpts_Retry(t *testing.T) {
+	ctx := context.Background()
+	noWait := func(int) time.Duration { return 0 }
+	errConflict := &crud.DBError{Kind: crud.ErrSerializationFailure}
+
+	t.Run("retries in a fresh transaction", func(t *testing.T) {
+		db := setupTransactorFileDB(t)
+		transactor := crud.NewTransactor(db)
+		repo := crud.NewRepository[TestModel](db)
+
+		var txs []*gorm.DB
+		opts := crud.TxOptions{Retry: &crud.RetryPolicy{MaxAttempts: 3, Backoff: noWait}}
+		err := transactor.WithinTransactionOpts(ctx, opts, func(txCtx context.Context) error {
+			tx, _ := crud.GetTxFromContext(txCtx)
+			txs = append(txs, tx)
+
+			if _, err := repo.Insert(txCtx, TestModel{Name: "attempt", Email: "attempt@example.com"}); err != nil {
+				return err
+			}
+			if len(txs) < 3 {
+				return eris.Wrap(errConflict, "error updating data")
+			}
+			return nil
+		})
+		assert.NoError(t, err, "WithinTransactionOpts should succeed on the last attempt")
+		assert.Len(t, txs, 3, "The function should run once per attempt")
+		assert.NotSame(t, txs[0], txs[1], "Each attempt should run in a new transaction")
+		assert.NotSame(t, txs[1], txs[2], "Each attempt should run in a new transaction")
+
+		count, err := repo.Count(ctx, crud.Specification[TestModel]{})
+		assert.NoError(t, err, "Count should not return error")
+		assert.Equal(t, int64(1), count, "Writes of failed attempts should be rolled back")
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		transactor := crud.NewTransactor(setupTransactorTestDB(t))
+		calls := 0
+
+		opts := crud.TxOptions{Retry: &crud.RetryPolicy{MaxAttempts: 2, Backoff: noWait}}
+		err := transactor.WithinTransactionOpts(ctx, opts, func(context.Context) error {
+			calls++
+			return errConflict
+		})
+		assert.ErrorIs(t, err, crud.ErrSerializationFailure, "WithinTransactionOpts should return the last error")
+		assert.Equal(t, 2, calls, "The function should run MaxAttempts times")
+	})
+
+	t.Run("does not retry other errors", func(t *testing.T) {
+		transactor := crud.NewTransactor(setupTransactorTestDB(t))
+		errFailed := errors.New("failed")
+		calls := 0
+
+		opts := crud.TxOptions{Retry: &crud.RetryPolicy{Backoff: noWait}}
+		err := transactor.WithinTransactionOpts(ctx, opts, func(context.Context) error {
+			calls++
+			return errFailed
+		})
+		assert.ErrorIs(t, err, errFailed, "WithinTransactionOpts should return the error")
+		assert.Equal(t, 1, calls, "Errors that are not retryable should not be retried")
+	})
+
+	t.Run("nested calls are retried by the outermost", func(t *testing.T) {
+		transactor := crud.NewTransactor(setupTransactorTestDB(t))
+		outer, inner := 0, 0
+
+		opts := crud.TxOptions{Retry: &crud.RetryPolicy{MaxAttempts: 2, Backoff: noWait}}
+		err := transactor.WithinTransactionOpts(ctx, opts, func(txCtx context.Context) error {
+			outer++
+			return transactor.WithinTransactionOpts(txCtx, opts, func(context.Context) error {
+				inner++
+				return errConflict
+			})
+		})
+		assert.ErrorIs(t, err, crud.ErrSerializationFailure, "WithinTransactionOpts should return the last error")
+		assert.Equal(t, 2, outer, "The outermost call should retry")
+		assert.Equal(t, 2, inner, "Nested calls should not retry on their own")
+	})
+
+	t.Run("retries a busy database", func(t *testing.T) {
+		db, err := gorm.Open(sqlite.Open(t.TempDir()+"/test.db?_txlock=immediate&_busy_timeout=0"), &gorm.Config{
+			Logger: logger.Default.LogMode(logger.Silent),
+		})
+		assert.NoError(t, err, "Failed to connect to test database")
+		transactor := crud.NewTransactor(db)
+
+		holder := db.Begin()
+		assert.NoError(t, holder.Error, "Failed to begin the lock holding transaction")
+
+		var waits []int
+		opts := crud.TxOptions{Retry: &crud.RetryPolicy{Backoff: func(attempt int) time.Duration {
+			waits = append(waits, attempt)
+			holder.Rollback() // release the lock before the next attempt
+			return 0
+		}}}
+		err = transactor.WithinTransactionOpts(ctx, opts, func(context.Context) error { return nil })
+		assert.NoError(t, err, "WithinTransactionOpts should succeed once the database is free")
+		assert.Equal(t, []int{1}, waits, "Beginning on a busy database should be retried")
+	})
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := crud.ExponentialBackoff(10*time.Millisecond, 50*time.Millisecond)
+
+	for range 20 {
+		assert.LessOrEqual(t, backoff(1), 10*time.Millisecond, "The first retry should wait at most the base delay")
+		assert.LessOrEqual(t, backoff(2), 20*time.Millisecond, "The delay should double on every retry")
+		assert.LessOrEqual(t, backoff(10), 50*time.Millisecond, "The delay should be capped")
+		assert.GreaterOrEqual(t, backoff(100), time.Duration(0), "Large attempts should not overflow")
+	}
+
+	// 5s<<31 overflows; the delay must be capped instead of turning negative
+	large := crud.ExponentialBackoff(5*time.Second, time.Minute)
+	waited := false
+	for range 20 {
+		delay := large(32)
+		assert.LessOrEqual(t, delay, time.Minute, "Large bases should be capped")
+		waited = waited || delay > 0
+	}
+	assert.True(t, waited, "Large bases should not overflow to no wait")
+}
+
+func TestTransactor_AfterCommit(t *testing.T) {
+	ctx := context.Background()
+	errFailed := errors.New("failed")
+
+	record := func(events *[]string, event string) func(context.Context) {
+		return func(hookCtx context.Context) {
+			tx, _ := crud.GetTxFromContext(hookCtx)
+			assert.Nil(t, tx, "Callbacks should not run within the ended transaction")
+			*events = append(*events, event)
+		}
+	}
+
+	t.Run("runs in order after commit", func(t *testing.T) {
+		transactor := crud.NewTransactor(setupTransactorTestDB(t))
+		var events []string
+
+		err := transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+			assert.NoError(t, crud.AfterCommit(txCtx, record(&events, "first")))
+			assert.NoError(t, crud.AfterCommit(txCtx, record(&events, "second")))
+			assert.NoError(t, crud.AfterRollback(txCtx, record(&events, "rollback")))
+			assert.Empty(t, events, "Callbacks should not run before the transaction ends")
+			return nil
+		})
+		assert.NoError(t, err, "WithinTransaction should not return error")
+		assert.Equal(t, []string{"first", "second"}, events, "Commit callbacks should run in order")
+	})
+
+	t.Run("runs rollback callbacks on failure", func(t *testing.T) {
+		transactor := crud.NewTransactor(setupTransactorTestDB(t))
+		var events []string
+
+		err := transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+			assert.NoError(t, crud.AfterCommit(txCtx, record(&events, "commit")))
+			assert.NoError(t, crud.AfterRollback(txCtx, record(&events, "first")))
+			assert.NoError(t, crud.AfterRollback(txCtx, record(&events, "second")))
+			return errFailed
+		})
+		assert.ErrorIs(t, err, errFailed, "WithinTransaction should return the error")
+		assert.Equal(t, []string{"first", "second"}, events, "Rollback callbacks should run in order")
+	})
+
+	t.Run("manual transactions", func(t *testing.T) {
+		transactor := crud.NewTransactor(setupTransactorTestDB(t))
+		var events []string
+
+		txCtx, err := transactor.Begin(ctx)
+		assert.NoError(t, err, "Begin should not return error")
+		assert.NoError(t, crud.AfterCommit(txCtx, record(&events, "commit")))
+		assert.NoError(t, transactor.Commit(txCtx), "Commit should not return error")
+		transactor.Rollback(txCtx)
+		assert.Equal(t, []string{"commit"}, events, "Callbacks should run once")
+
+		assert.Error(t, crud.AfterCommit(txCtx, record(&events, "late")), "AfterCommit should fail on an ended transaction")
+	})
+
+	t.Run("timed out transaction", func(t *testing.T) {
+		transactor := crud.NewTransactor(setupTransactorFileDB(t))
+		var events []string
+
+		opts := crud.TxOptions{Timeout: 50 * time.Millisecond}
+		err := transactor.WithinTransactionOpts(ctx, opts, func(txCtx context.Context) error {
+			assert.NoError(t, crud.AfterCommit(txCtx, record(&events, "commit")))
+			assert.NoError(t, crud.AfterRollback(txCtx, record(&events, "rollback")))
+			<-txCtx.Done()
+			return txCtx.Err()
+		})
+		assert.ErrorIs(t, err, context.DeadlineExceeded, "WithinTransactionOpts should report the timeout")
+		assert.Equal(t, []string{"rollback"}, events, "Rollback callbacks should run when the timeout rolls back")
+	})
+
+	t.Run("without transaction", func(t *testing.T) {
+		var events []string
+
+		assert.NoError(t, crud.AfterCommit(ctx, record(&events, "commit")))
+		assert.NoError(t, crud.AfterRollback(ctx, record(&events, "rollback")))
+		assert.Equal(t, []string{"commit", "rollback"}, events, "Callbacks should run immediately without a transaction")
+	})
+
+	t.Run("savepoints", func(t *testing.T) {
+		transactor := crud.NewTransactor(setupTransactorTestDB(t))
+		var events []string
+
+		err := transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+			assert.NoError(t, crud.AfterCommit(txCtx, record(&events, "outer")))
+
+			err := transactor.WithinTransaction(txCtx, func(innerCtx context.Context) error {
+				assert.NoError(t, crud.AfterCommit(innerCtx, record(&events, "failed inner")))
+				assert.NoError(t, crud.AfterRollback(innerCtx, record(&events, "inner rolled back")))
+				return errFailed
+			})
+			assert.ErrorIs(t, err, errFailed, "Nested WithinTransaction should return the error")
+			assert.Equal(t, []string{"inner rolled back"}, events, "Rollback callbacks should run when the savepoint rolls back")
+
+			return transactor.WithinTransaction(txCtx, func(innerCtx context.Context) error {
+				return crud.AfterCommit(innerCtx, record(&events, "inner"))
+			})
+		})
+		assert.NoError(t, err, "WithinTransaction should not return error")
+		assert.Equal(t, []string{"inner rolled back", "outer", "inner"}, events, "Commit callbacks of rolled back savepoints should be dropped")
+	})
+
+	t.Run("foreign transaction", func(t *testing.T) {
+		db := setupTransactorTestDB(t)
+		tx := db.Begin()
+		defer tx.Rollback()
+
+		txCtx := context.WithValue(ctx, lib.ContextKeyGormTx, tx)
+		assert.Error(t, crud.AfterCommit(txCtx, func(context.Context) {}), "AfterCommit should fail for transactions it cannot track")
+	})
+}
@@ -0,0 +1,154 @@
+package gocrud_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	crud "github.com/itsLeonB/go-crud"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// UpsertModel has a natural key of (TenantID, Email)
+type UpsertModel struct {
+	ID        uint   `gorm:"primaryKey"`
+	TenantID  uint   `gorm:"uniqueIndex:idx_tenant_email"`
+	Email     string `gorm:"uniqueIndex:idx_tenant_email"`
+	Name      string
+	Visits    int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func setupUpsertTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	assert.NoError(t, err, "Failed to connect to test database")
+
+	err = db.AutoMigrate(&UpsertModel{})
+	assert.NoError(t, err, "Failed to migrate test models")
+
+	return db
+}
+
+func TestRepository_Upsert(t *testing.T) {
+	ctx := context.Background()
+	naturalKey := []string{"TenantID", "email"}
+
+	seed := func(t *testing.T) (*gorm.DB, crud.Repository[UpsertModel], UpsertModel) {
+		db := setupUpsertTestDB(t)
+		repo := crud.NewRepository[UpsertModel](db)
+		existing, err := repo.Insert(ctx, UpsertModel{TenantID: 1, Email: "alice@example.com", Name: "Alice", Visits: 1})
+		assert.NoError(t, err, "Failed to insert test data")
+		return db, repo, existing
+	}
+	load := func(t *testing.T, db *gorm.DB) []UpsertModel {
+		var models []UpsertModel
+		err := db.Order("id").Find(&models).Error
+		assert.NoError(t, err, "Failed to load records")
+		return models
+	}
+
+	t.Run("update all", func(t *testing.T) {
+		db, repo, existing := seed(t)
+
+		upserted, err := repo.Upsert(ctx, UpsertModel{TenantID: 1, Email: "alice@example.com", Name: "Alicia", Visits: 2}, crud.UpsertOptions{ConflictColumns: naturalKey})
+		assert.NoError(t, err, "Upsert should not return error")
+		assert.Equal(t, existing.ID, upserted.ID, "Upsert should return the existing record's ID")
+
+		models := load(t, db)
+		assert.Len(t, models, 1, "Upsert should not insert a duplicate")
+		assert.Equal(t, "Alicia", models[0].Name, "Upsert should overwrite columns")
+		assert.Equal(t, 2, models[0].Visits, "Upsert should overwrite columns")
+		assert.WithinDuration(t, existing.CreatedAt, models[0].CreatedAt, time.Millisecond, "Upsert should keep the creation timestamp")
+	})
+
+	t.Run("update all except", func(t *testing.T) {
+		db, repo, _ := seed(t)
+
+		_, err := repo.Upsert(ctx, UpsertModel{TenantID: 1, Email: "alice@example.com", Name: "Alicia", Visits: 2}, crud.UpsertOptions{
+			ConflictColumns: naturalKey,
+			UpdateAllExcept: []string{"Name"},
+		})
+		assert.NoError(t, err, "Upsert should not return error")
+
+		models := load(t, db)
+		assert.Equal(t, "Alice", models[0].Name, "Upsert should keep excluded columns")
+		assert.Equal(t, 2, models[0].Visits, "Upsert should overwrite other columns")
+	})
+
+	t.Run("update columns", func(t *testing.T) {
+		db, repo, _ := seed(t)
+
+		_, err := repo.Upsert(ctx, UpsertModel{TenantID: 1, Email: "alice@example.com", Name: "Alicia", Visits: 2}, crud.UpsertOptions{
+			ConflictColumns: naturalKey,
+			UpdateColumns:   []string{"visits"},
+		})
+		assert.NoError(t, err, "Upsert should not return error")
+
+		models := load(t, db)
+		assert.Equal(t, "Alice", models[0].Name, "Upsert should only overwrite listed columns")
+		assert.Equal(t, 2, models[0].Visits, "Upsert should overwrite listed columns")
+	})
+
+	t.Run("do nothing", func(t *testing.T) {
+		db, repo, _ := seed(t)
+
+		existing, err := repo.Upsert(ctx, UpsertModel{TenantID: 1, Email: "alice@example.com", Name: "Alicia"}, crud.UpsertOptions{
+			ConflictColumns: naturalKey,
+			DoNothing:       true,
+		})
+		assert.NoError(t, err, "Upsert should not return error")
+
+		models := load(t, db)
+		assert.Len(t, models, 1, "Upsert should not insert a duplicate")
+		assert.Equal(t, "Alice", models[0].Name, "DoNothing should keep the existing record")
+		assert.Equal(t, models[0].ID, existing.ID, "DoNothing should return the existing record")
+		assert.Equal(t, "Alice", existing.Name, "DoNothing should return the stored values")
+	})
+
+	t.Run("many", func(t *testing.T) {
+		db, repo, _ := seed(t)
+
+		upserted, err := repo.UpsertMany(ctx, []UpsertModel{
+			{TenantID: 1, Email: "alice@example.com", Name: "Alicia"},
+			{TenantID: 2, Email: "alice@example.com", Name: "Other Alice"},
+		}, crud.UpsertOptions{ConflictColumns: naturalKey, UpdateColumns: []string{"name"}})
+		assert.NoError(t, err, "UpsertMany should not return error")
+		assert.Len(t, upserted, 2, "UpsertMany should return all models")
+
+		models := load(t, db)
+		assert.Len(t, models, 2, "UpsertMany should insert new and update existing records")
+		assert.Equal(t, "Alicia", models[0].Name, "UpsertMany should update the conflicting record")
+		assert.Equal(t, "Other Alice", models[1].Name, "UpsertMany should insert the new record")
+	})
+
+	t.Run("primary key conflict", func(t *testing.T) {
+		db, repo, existing := seed(t)
+
+		_, err := repo.Upsert(ctx, UpsertModel{ID: existing.ID, TenantID: 1, Email: "alice@example.com", Name: "Alicia"}, crud.UpsertOptions{})
+		assert.NoError(t, err, "Upsert should default to the primary key")
+		assert.Equal(t, "Alicia", load(t, db)[0].Name, "Upsert should overwrite the record with the same primary key")
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		_, repo, _ := seed(t)
+		model := UpsertModel{TenantID: 1, Email: "bob@example.com"}
+
+		_, err := repo.Upsert(ctx, model, crud.UpsertOptions{DoNothing: true, UpdateColumns: []string{"name"}})
+		assert.Error(t, err, "Upsert should reject DoNothing with update columns")
+
+		_, err = repo.Upsert(ctx, model, crud.UpsertOptions{UpdateColumns: []string{"name"}, UpdateAllExcept: []string{"visits"}})
+		assert.Error(t, err, "Upsert should reject UpdateColumns with UpdateAllExcept")
+
+		_, err = repo.Upsert(ctx, model, crud.UpsertOptions{ConflictColumns: []string{"email); DROP TABLE upsert_models; --"}})
+		assert.Error(t, err, "Upsert should reject invalid column names")
+
+		_, err = repo.UpsertMany(ctx, nil, crud.UpsertOptions{})
+		assert.Error(t, err, "UpsertMany should reject empty slice")
+	})
+}
@@ -0,0 +1,193 @@
+package gocrud_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	crud "github.com/itsLeonB/go-crud"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// VersionedModel uses optimistic locking through VersionedEntity
+type VersionedModel struct {
+	ID    uint   `gorm:"primaryKey"`
+	Name  string `gorm:"uniqueIndex"`
+	Stock int
+	crud.VersionedEntity
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ReleaseModel has a Version field that is not meant for optimistic locking
+type ReleaseModel struct {
+	ID      uint `gorm:"primaryKey"`
+	Name    string
+	Version int
+}
+
+// SoftVersionedModel combines optimistic locking with BaseEntity's sql.NullTime soft delete
+type SoftVersionedModel struct {
+	ID   uint `gorm:"primaryKey"`
+	Name string
+	crud.VersionedEntity
+	DeletedAt sql.NullTime
+}
+
+func setupVersionTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	assert.NoError(t, err, "Failed to connect to test database")
+
+	err = db.AutoMigrate(&VersionedModel{}, &ReleaseModel{}, &SoftVersionedModel{})
+	assert.NoError(t, err, "Failed to migrate test models")
+
+	return db
+}
+
+func TestRepository_Version(t *testing.T) {
+	db := setupVersionTestDB(t)
+	repo := crud.NewRepository[VersionedModel](db)
+	ctx := context.Background()
+
+	inserted, err := repo.Insert(ctx, VersionedModel{Name: "Widget", Stock: 10})
+	assert.NoError(t, err, "Failed to insert test data")
+	assert.Equal(t, int64(1), inserted.Version, "Insert should start at version 1")
+
+	t.Run("update", func(t *testing.T) {
+		first, second := inserted, inserted
+
+		first.Stock = 9
+		updated, err := repo.Update(ctx, first)
+		assert.NoError(t, err, "Update should not return error")
+		assert.Equal(t, int64(2), updated.Version, "Update should increment the version")
+
+		second.Stock = 8
+		_, err = repo.Update(ctx, second)
+		assert.True(t, errors.Is(err, crud.ErrStaleEntity), "Update should reject a stale model")
+
+		_, err = repo.Update(ctx, VersionedModel{ID: 999, Name: "Missing"})
+		assert.True(t, errors.Is(err, crud.ErrNotFound), "Update should not insert missing records")
+
+		inserted = updated
+	})
+
+	t.Run("update fields", func(t *testing.T) {
+		stale := inserted
+
+		inserted.Stock = 7
+		updated, err := repo.UpdateFields(ctx, inserted, "stock")
+		assert.NoError(t, err, "UpdateFields should not return error")
+		assert.Equal(t, int64(3), updated.Version, "UpdateFields should increment the version")
+
+		stale.Stock = 6
+		_, err = repo.UpdateFields(ctx, stale, "stock")
+		assert.True(t, errors.Is(err, crud.ErrStaleEntity), "UpdateFields should reject a stale model")
+
+		inserted = updated
+	})
+
+	t.Run("unguarded writes increment the version", func(t *testing.T) {
+		updated, err := repo.UpdateMap(ctx, inserted.ID, map[string]any{"stock": 5})
+		assert.NoError(t, err, "UpdateMap should not return error")
+		assert.Equal(t, inserted.Version+1, updated.Version, "UpdateMap should increment the version")
+
+		_, err = repo.Update(ctx, inserted)
+		assert.True(t, errors.Is(err, crud.ErrStaleEntity), "Update should see changes made by UpdateMap")
+
+		upserted, err := repo.Upsert(ctx, VersionedModel{Name: "Widget", Stock: 4}, crud.UpsertOptions{ConflictColumns: []string{"name"}})
+		assert.NoError(t, err, "Upsert should not return error")
+
+		var stored VersionedModel
+		err = db.First(&stored, updated.ID).Error
+		assert.NoError(t, err, "Failed to load record")
+		assert.Equal(t, updated.Version+1, stored.Version, "Upsert should increment the version on conflict")
+		assert.Equal(t, 4, stored.Stock, "Upsert should overwrite other columns")
+		assert.Equal(t, updated.ID, upserted.ID, "Upsert should return the existing record's ID")
+
+		inserted = stored
+	})
+
+	t.Run("save many", func(t *testing.T) {
+		stale := inserted
+		inserted.Stock = 3
+
+		saved, err := repo.SaveMany(ctx, []VersionedModel{inserted, {Name: "Gadget", Stock: 1}})
+		assert.NoError(t, err, "SaveMany should not return error")
+		assert.Equal(t, inserted.Version+1, saved[0].Version, "SaveMany should increment existing versions")
+		assert.NotZero(t, saved[1].ID, "SaveMany should insert new records")
+		assert.Equal(t, int64(1), saved[1].Version, "SaveMany should start new records at version 1")
+
+		saved[1].Stock = 2
+		_, err = repo.SaveMany(ctx, []VersionedModel{saved[1], stale})
+		assert.True(t, errors.Is(err, crud.ErrStaleEntity), "SaveMany should reject a stale model")
+
+		var gadget VersionedModel
+		err = db.First(&gadget, saved[1].ID).Error
+		assert.NoError(t, err, "Failed to load record")
+		assert.Equal(t, 1, gadget.Stock, "SaveMany should roll back every model when one is stale")
+	})
+}
+
+func TestRepository_VersionColumn(t *testing.T) {
+	db := setupVersionTestDB(t)
+	ctx := context.Background()
+
+	t.Run("not detected outside VersionedEntity", func(t *testing.T) {
+		repo := crud.NewRepository[ReleaseModel](db)
+		inserted, err := repo.Insert(ctx, ReleaseModel{Name: "api", Version: 3})
+		assert.NoError(t, err, "Failed to insert test data")
+
+		inserted.Version = 4
+		updated, err := repo.Update(ctx, inserted)
+		assert.NoError(t, err, "Update should not check an unrelated Version field")
+		assert.Equal(t, 4, updated.Version, "Update should write Version as given")
+
+		_, err = repo.UpdateMap(ctx, inserted.ID, map[string]any{"name": "api-v4"})
+		assert.NoError(t, err, "UpdateMap should not return error")
+		found, err := repo.FindOne(ctx, crud.Specification[ReleaseModel]{Model: ReleaseModel{ID: inserted.ID}})
+		assert.NoError(t, err, "FindOne should not return error")
+		assert.Equal(t, 4, found.Version, "UpdateMap should not increment an unrelated Version field")
+	})
+
+	t.Run("opt in WithVersionColumn", func(t *testing.T) {
+		repo := crud.NewRepository[ReleaseModel](db, crud.WithVersionColumn("Version"))
+		inserted, err := repo.Insert(ctx, ReleaseModel{Name: "web", Version: 1})
+		assert.NoError(t, err, "Failed to insert test data")
+
+		first, second := inserted, inserted
+		_, err = repo.Update(ctx, first)
+		assert.NoError(t, err, "Update should not return error")
+		_, err = repo.Update(ctx, second)
+		assert.ErrorIs(t, err, crud.ErrStaleEntity, "Update should reject a stale model")
+	})
+}
+
+func TestRepository_Version_SoftDeleted(t *testing.T) {
+	db := setupVersionTestDB(t)
+	repo := crud.NewRepository[SoftVersionedModel](db)
+	ctx := context.Background()
+
+	inserted, err := repo.Insert(ctx, SoftVersionedModel{Name: "Widget"})
+	assert.NoError(t, err, "Failed to insert test data")
+	err = repo.SoftDelete(ctx, inserted)
+	assert.NoError(t, err, "SoftDelete should not return error")
+
+	inserted.Name = "Gadget"
+	_, err = repo.Update(ctx, inserted)
+	assert.ErrorIs(t, err, crud.ErrNotFound, "Update should not write soft-deleted records")
+
+	stored, err := repo.FindOne(ctx, crud.Specification[SoftVersionedModel]{
+		Model:         SoftVersionedModel{ID: inserted.ID},
+		DeletedFilter: crud.OnlyDeleted,
+	})
+	assert.NoError(t, err, "FindOne should not return error")
+	assert.Equal(t, "Widget", stored.Name, "Update should leave soft-deleted records untouched")
+	assert.Equal(t, int64(1), stored.Version, "Update should not bump the version of soft-deleted records")
+}
@@ -0,0 +1,184 @@
+package crud
+
+import (
+	"context"
+	"reflect"
+	"slices"
+
+	"github.com/itsLeonB/go-crud/internal"
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// UpsertOptions describes how Upsert and UpsertMany resolve a conflict with an existing record.
+// Without DoNothing or UpdateColumns, every column except the conflict columns, the primary key,
+// the creation timestamp and UpdateAllExcept is overwritten.
+// Fields accept column or struct field names and are validated against the model's schema.
+type UpsertOptions struct {
+	// ConflictColumns is the unique key that identifies an existing record. Defaults to the primary key.
+	// MySQL ignores it and resolves conflicts on any unique key.
+	ConflictColumns []string
+	// DoNothing keeps the existing record untouched. Upsert then returns the existing record, while
+	// UpsertMany leaves the primary key of skipped models zero.
+	DoNothing bool
+	// UpdateColumns lists the only columns overwritten on conflict.
+	UpdateColumns []string
+	// UpdateAllExcept lists columns kept from the existing record on conflict.
+	UpdateAllExcept []string
+}
+
+func (gr *gormRepository[T]) Upsert(ctx context.Context, model T, opts UpsertOptions) (T, error) {
+	var zero T
+
+	if err := gr.checkZeroValue(model); err != nil {
+		return zero, err
+	}
+
+	onConflict, err := gr.onConflict(opts)
+	if err != nil {
+		return zero, err
+	}
+
+	db, err := gr.GetGormInstance(ctx)
+	if err != nil {
+		return zero, err
+	}
+
+	result := db.Clauses(onConflict).Create(&model)
+	if result.Error != nil {
+		return zero, eris.Wrap(internal.ClassifyError(result.Error), "error upserting data")
+	}
+	if opts.DoNothing && result.RowsAffected == 0 {
+		// Nothing was written or returned, so load the record that caused the conflict
+		return gr.loadConflicting(ctx, db, onConflict.Columns, model)
+	}
+
+	return model, nil
+}
+
+// loadConflicting loads the existing record with the same conflict column values as model.
+func (gr *gormRepository[T]) loadConflicting(ctx context.Context, db *gorm.DB, columns []clause.Column, model T) (T, error) {
+	var existing T
+
+	meta, err := gr.meta()
+	if err != nil {
+		return existing, err
+	}
+
+	rv := reflect.ValueOf(&model).Elem()
+	query := db.Unscoped().Model(new(T))
+	for _, column := range columns {
+		value, _ := meta.schema.LookUpField(column.Name).ValueOf(ctx, rv)
+		query = query.Where(clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: column.Name}, Value: value})
+	}
+
+	if err = query.Take(&existing).Error; err != nil {
+		return existing, eris.Wrap(internal.ClassifyError(err), "error loading conflicting data")
+	}
+
+	return existing, nil
+}
+
+func (gr *gormRepository[T]) UpsertMany(ctx context.Context, models []T, opts UpsertOptions) ([]T, error) {
+	if len(models) < 1 {
+		return nil, eris.Errorf("upserted models cannot be empty")
+	}
+
+	onConflict, err := gr.onConflict(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := gr.GetGormInstance(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = db.Clauses(onConflict).Create(&models).Error; err != nil {
+		return nil, eris.Wrap(internal.ClassifyError(err), "error batch upserting data")
+	}
+
+	return models, nil
+}
+
+// onConflict translates opts into an ON CONFLICT clause using the model's column names.
+func (gr *gormRepository[T]) onConflict(opts UpsertOptions) (clause.OnConflict, error) {
+	var onConflict clause.OnConflict
+
+	if opts.DoNothing && (len(opts.UpdateColumns) > 0 || len(opts.UpdateAllExcept) > 0) {
+		return onConflict, eris.New("upsert cannot combine DoNothing with update columns")
+	}
+	if len(opts.UpdateColumns) > 0 && len(opts.UpdateAllExcept) > 0 {
+		return onConflict, eris.New("upsert cannot combine UpdateColumns with UpdateAllExcept")
+	}
+
+	meta, err := gr.meta()
+	if err != nil {
+		return onConflict, err
+	}
+	sch := meta.schema
+
+	conflict, err := lookupColumns(sch, opts.ConflictColumns)
+	if err != nil {
+		return onConflict, err
+	}
+	if len(conflict) == 0 {
+		if len(sch.PrimaryFieldDBNames) == 0 {
+			return onConflict, eris.Errorf("%s has no primary key to detect conflicts on", sch.Name)
+		}
+		conflict = sch.PrimaryFieldDBNames
+	}
+	for _, name := range conflict {
+		onConflict.Columns = append(onConflict.Columns, clause.Column{Name: name})
+	}
+
+	if opts.DoNothing {
+		onConflict.DoNothing = true
+		return onConflict, nil
+	}
+
+	updates, err := lookupColumns(sch, opts.UpdateColumns)
+	if err != nil {
+		return onConflict, err
+	}
+	if len(updates) == 0 {
+		except, err := lookupColumns(sch, opts.UpdateAllExcept)
+		if err != nil {
+			return onConflict, err
+		}
+		for _, field := range sch.Fields {
+			if field.DBName == "" || field.PrimaryKey || !field.Updatable || field.AutoCreateTime != 0 ||
+				field.DBName == meta.versionColumn || slices.Contains(conflict, field.DBName) || slices.Contains(except, field.DBName) {
+				continue
+			}
+			updates = append(updates, field.DBName)
+		}
+	}
+	if len(updates) == 0 {
+		return onConflict, eris.New("upsert has no columns to update")
+	}
+
+	onConflict.DoUpdates = clause.AssignmentColumns(updates)
+	if meta.versionColumn != "" && !slices.Contains(updates, meta.versionColumn) {
+		onConflict.DoUpdates = append(onConflict.DoUpdates, clause.Assignment{
+			Column: clause.Column{Name: meta.versionColumn},
+			Value:  meta.versionIncrement(),
+		})
+	}
+	return onConflict, nil
+}
+
+// lookupColumns resolves field names against the schema and returns their column names.
+func lookupColumns(sch *schema.Schema, names []string) ([]string, error) {
+	columns := make([]string, 0, len(names))
+	for _, name := range names {
+		field, err := internal.LookupField(sch, name)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, field.DBName)
+	}
+	return columns, nil
+}
@@ -0,0 +1,128 @@
+package crud
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/itsLeonB/go-crud/internal"
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// updateVersioned writes every column of model, like Save, but only when the stored version
+// still matches the model's, incrementing it in the same statement.
+func (gr *gormRepository[T]) updateVersioned(ctx context.Context, db *gorm.DB, meta *modelMeta, model T) (T, error) {
+	var zero T
+
+	pk, err := meta.primaryKey()
+	if err != nil {
+		return zero, err
+	}
+
+	rv := reflect.ValueOf(&model).Elem()
+	id, isZero := pk.ValueOf(ctx, rv)
+	if isZero {
+		return zero, eris.New("model has no primary key value")
+	}
+
+	guard, _, err := meta.bumpVersion(ctx, rv)
+	if err != nil {
+		return zero, err
+	}
+
+	result := db.Model(&model).
+		Scopes(gr.deletedScope(DeletedFilter{})).
+		Where(clause.Eq{Column: pkColumn(pk), Value: id}).
+		Where(guard).
+		Select("*").
+		Updates(&model)
+
+	if result.Error != nil {
+		return zero, eris.Wrap(internal.ClassifyError(result.Error), "error updating data")
+	}
+	if result.RowsAffected == 0 {
+		return zero, gr.staleOrNotFound(db, pk, id, "error updating data")
+	}
+
+	return model, nil
+}
+
+// saveManyVersioned inserts new models and applies a versioned update to existing ones, all within one transaction.
+func (gr *gormRepository[T]) saveManyVersioned(ctx context.Context, db *gorm.DB, meta *modelMeta, models []T) ([]T, error) {
+	pk, err := meta.primaryKey()
+	if err != nil {
+		return nil, err
+	}
+
+	saved := make([]T, len(models))
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for i, model := range models {
+			if _, isZero := pk.ValueOf(ctx, reflect.ValueOf(&model).Elem()); isZero {
+				if err := tx.Create(&model).Error; err != nil {
+					return eris.Wrap(internal.ClassifyError(err), "error saving many data")
+				}
+				saved[i] = model
+				continue
+			}
+
+			updated, err := gr.updateVersioned(ctx, tx, meta, model)
+			if err != nil {
+				return err
+			}
+			saved[i] = updated
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+// bumpVersion increments the version of the model in rv and returns the condition matching its previous version,
+// along with the new version.
+func (meta *modelMeta) bumpVersion(ctx context.Context, rv reflect.Value) (clause.Expression, int64, error) {
+	field := meta.schema.LookUpField(meta.versionColumn)
+	value, _ := field.ValueOf(ctx, rv)
+
+	var current int64
+	if v := reflect.ValueOf(value); v.CanInt() {
+		current = v.Int()
+	} else {
+		current = int64(v.Uint())
+	}
+
+	if err := field.Set(ctx, rv, current+1); err != nil {
+		return nil, 0, eris.Wrap(err, "error incrementing version")
+	}
+
+	return clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: current}, current + 1, nil
+}
+
+// versionIncrement returns the assignment value that increments the version column in place.
+func (meta *modelMeta) versionIncrement() clause.Expr {
+	return gorm.Expr("? + 1", clause.Column{Table: clause.CurrentTable, Name: meta.versionColumn})
+}
+
+// staleOrNotFound tells a version mismatch apart from a missing record after an update matched no rows.
+func (gr *gormRepository[T]) staleOrNotFound(db *gorm.DB, pk *schema.Field, id any, msg string) error {
+	var count int64
+	err := db.Model(new(T)).
+		Scopes(gr.deletedScope(DeletedFilter{})).
+		Where(clause.Eq{Column: pkColumn(pk), Value: id}).
+		Count(&count).
+		Error
+
+	if err != nil {
+		return eris.Wrap(internal.ClassifyError(err), msg)
+	}
+	if count > 0 {
+		return eris.Wrap(ErrStaleEntity, msg)
+	}
+
+	return eris.Wrap(ErrNotFound, msg)
+}